@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type AccountHandler struct {
+	accountService service.AccountService
+	log            *zap.Logger
+}
+
+func NewAccountHandler(accountService service.AccountService, logger *zap.Logger) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		log:            logger,
+	}
+}
+
+func (h *AccountHandler) DeleteMe(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(c.Request.Context(), user); err != nil {
+		h.log.Error("Delete Account Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AccountHandler) ExportMe(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.accountService.ExportData(c.Request.Context(), user)
+	if err != nil {
+		h.log.Error("Export Account Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="agile-sync-export.json"`)
+	c.JSON(http.StatusOK, export)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type AdminHandler struct {
+	adminService service.AdminService
+	log          *zap.Logger
+}
+
+func NewAdminHandler(adminService service.AdminService, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		log:          logger,
+	}
+}
+
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	actor, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(service.ErrUserNotFound)
+		return
+	}
+
+	var req apimodel.UserRoleUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	user, err := h.adminService.SetUserRole(c.Request.Context(), actor, userID, req.Role)
+	if err != nil {
+		h.log.Info("Set User Role Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToUserProfile(user))
+}
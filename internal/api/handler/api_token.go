@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type APITokenHandler struct {
+	apiTokenService service.APITokenService
+	log             *zap.Logger
+}
+
+func NewAPITokenHandler(apiTokenService service.APITokenService, logger *zap.Logger) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenService: apiTokenService,
+		log:             logger,
+	}
+}
+
+func (h *APITokenHandler) Create(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.APITokenCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	resp, err := h.apiTokenService.Create(c.Request.Context(), user, &req)
+	if err != nil {
+		h.log.Info("Create API Token Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *APITokenHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.apiTokenService.List(c.Request.Context(), user)
+	if err != nil {
+		h.log.Info("List API Tokens Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *APITokenHandler) Revoke(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(service.ErrAPITokenNotFound)
+		return
+	}
+
+	if err := h.apiTokenService.Revoke(c.Request.Context(), user, id); err != nil {
+		h.log.Info("Revoke API Token Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,9 +1,9 @@
 package handler
 
 import (
+	"backend_go/internal/api/validation"
 	"backend_go/internal/model/apimodel"
 	"backend_go/internal/model/converter"
-	"backend_go/internal/model/entitymodel"
 	"backend_go/internal/service"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -11,14 +11,20 @@ import (
 )
 
 type AuthHandler struct {
-	authService service.AuthService
-	log         *zap.Logger
+	authService         service.AuthService
+	verificationService service.VerificationService
+	log                 *zap.Logger
 }
 
-func NewAuthHandler(authService service.AuthService, logger *zap.Logger) *AuthHandler {
+func NewAuthHandler(
+	authService service.AuthService,
+	verificationService service.VerificationService,
+	logger *zap.Logger,
+) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		log:         logger,
+		authService:         authService,
+		verificationService: verificationService,
+		log:                 logger,
 	}
 }
 
@@ -27,14 +33,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	if err := c.ShouldBind(&req); err != nil {
 		h.log.Info("Bind Error", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(validation.ToServiceError(err))
 		return
 	}
 
-	resp, err := h.authService.Login(c.Request.Context(), &req)
+	resp, err := h.authService.Login(c.Request.Context(), &req, c.ClientIP())
 	if err != nil {
 		h.log.Info("Login Error", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -42,7 +48,45 @@ func (h *AuthHandler) Login(c *gin.Context) {
 }
 
 func (h *AuthHandler) GuestLogin(c *gin.Context) {
-	h.log.Info("Handle Guest Logout")
+	var req apimodel.GuestLogin
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	resp, err := h.authService.GuestLogin(c.Request.Context(), &req)
+	if err != nil {
+		h.log.Info("Guest Login Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AuthHandler) UpgradeGuest(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.GuestUpgrade
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	resp, err := h.authService.UpgradeGuest(c.Request.Context(), user, &req)
+	if err != nil {
+		h.log.Info("Upgrade Guest Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -50,7 +94,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Info("Bind Error", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(validation.ToServiceError(err))
 		return
 	}
 
@@ -59,7 +103,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	resp, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
 		h.log.Info("Register Error", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -67,19 +111,91 @@ func (h *AuthHandler) Register(c *gin.Context) {
 }
 
 func (h *AuthHandler) Me(c *gin.Context) {
-	userInterface, exists := c.Get("user")
-	if !exists {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "User not found in context"})
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	userProfile := converter.ToUserProfile(user)
+
+	c.JSON(http.StatusOK, userProfile)
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req apimodel.EmailVerify
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
 		return
 	}
 
-	user, ok := userInterface.(*entitymodel.User)
+	if err := h.verificationService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		h.log.Info("Verify Email Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	user, ok := currentUser(c)
 	if !ok {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
 		return
 	}
 
-	userProfile := converter.ToUserProfile(user)
+	if err := h.verificationService.SendVerification(c.Request.Context(), user); err != nil {
+		h.log.Info("Resend Verification Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
 
-	c.JSON(http.StatusOK, userProfile)
+	c.Status(http.StatusAccepted)
+}
+
+func (h *AuthHandler) UpdateMe(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.UserProfileUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	updatedUser, err := h.authService.UpdateProfile(c.Request.Context(), user, &req)
+	if err != nil {
+		h.log.Info("Update Profile Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToUserProfile(updatedUser))
+}
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.PasswordChange
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	resp, err := h.authService.ChangePassword(c.Request.Context(), user, &req)
+	if err != nil {
+		h.log.Info("Change Password Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
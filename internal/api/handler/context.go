@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"backend_go/internal/model/entitymodel"
+	"errors"
+	"github.com/gin-gonic/gin"
+)
+
+// currentUser достаёт пользователя, положенного в контекст AuthMiddleware.
+// При ошибке цепочка прервана и ответ сформирует ErrorHandler, обработчик должен просто завершиться.
+func currentUser(c *gin.Context) (*entitymodel.User, bool) {
+	userInterface, exists := c.Get("user")
+	if !exists {
+		_ = c.Error(errors.New("user not found in context"))
+		c.Abort()
+		return nil, false
+	}
+
+	user, ok := userInterface.(*entitymodel.User)
+	if !ok {
+		_ = c.Error(errors.New("invalid user type in context"))
+		c.Abort()
+		return nil, false
+	}
+
+	return user, true
+}
+
+// optionalUser возвращает пользователя для маршрутов с OptionalAuthMiddleware или nil, если запрос анонимный
+func optionalUser(c *gin.Context) *entitymodel.User {
+	value, exists := c.Get("user")
+	if !exists {
+		return nil
+	}
+	user, _ := value.(*entitymodel.User)
+	return user
+}
+
+// currentParticipant достаёт участника сессии, положенного в контекст RequireSessionRole
+func currentParticipant(c *gin.Context) (*entitymodel.SessionParticipant, bool) {
+	value, exists := c.Get("session_participant")
+	if !exists {
+		_ = c.Error(errors.New("session participant not found in context"))
+		c.Abort()
+		return nil, false
+	}
+
+	participant, ok := value.(*entitymodel.SessionParticipant)
+	if !ok {
+		_ = c.Error(errors.New("invalid session participant type in context"))
+		c.Abort()
+		return nil, false
+	}
+
+	return participant, true
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type InviteHandler struct {
+	inviteService service.InviteService
+	log           *zap.Logger
+}
+
+func NewInviteHandler(inviteService service.InviteService, logger *zap.Logger) *InviteHandler {
+	return &InviteHandler{
+		inviteService: inviteService,
+		log:           logger,
+	}
+}
+
+func (h *InviteHandler) Create(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionInviteCreate
+	// Тело необязательно: без него ссылка бессрочная и без лимита входов
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Info("Bind Error", zap.Error(err))
+			_ = c.Error(validation.ToServiceError(err))
+			return
+		}
+	}
+
+	invite, err := h.inviteService.Create(c.Request.Context(), participant, &req)
+	if err != nil {
+		h.log.Info("Create Invite Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+func (h *InviteHandler) List(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	invites, err := h.inviteService.List(c.Request.Context(), participant.SessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+func (h *InviteHandler) Revoke(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	inviteID := c.Param("invite_id")
+	if _, err := uuid.Parse(inviteID); err != nil {
+		_ = c.Error(service.ErrInvalidInvite)
+		return
+	}
+
+	if err := h.inviteService.Revoke(c.Request.Context(), participant, inviteID); err != nil {
+		h.log.Info("Revoke Invite Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *InviteHandler) Preview(c *gin.Context) {
+	preview, err := h.inviteService.Preview(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Accept работает и без авторизации: тогда по имени из тела создаётся гость
+func (h *InviteHandler) Accept(c *gin.Context) {
+	var req apimodel.SessionInviteAccept
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Info("Bind Error", zap.Error(err))
+			_ = c.Error(validation.ToServiceError(err))
+			return
+		}
+	}
+
+	resp, err := h.inviteService.Accept(c.Request.Context(), optionalUser(c), c.Param("token"), &req)
+	if err != nil {
+		h.log.Info("Accept Invite Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type MFAHandler struct {
+	mfaService service.MFAService
+	log        *zap.Logger
+}
+
+func NewMFAHandler(mfaService service.MFAService, logger *zap.Logger) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+		log:        logger,
+	}
+}
+
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.mfaService.Enroll(c.Request.Context(), user)
+	if err != nil {
+		h.log.Info("MFA Enroll Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *MFAHandler) Confirm(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.MFACode
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	resp, err := h.mfaService.Confirm(c.Request.Context(), user, req.Code, c.ClientIP())
+	if err != nil {
+		h.log.Info("MFA Confirm Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *MFAHandler) Disable(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.MFACode
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), user, req.Code, c.ClientIP()); err != nil {
+		h.log.Info("MFA Disable Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Verify - второй шаг входа: обменивает mfa_token и код на пару JWT
+func (h *MFAHandler) Verify(c *gin.Context) {
+	var req apimodel.MFAVerify
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	resp, err := h.mfaService.Verify(c.Request.Context(), &req, c.ClientIP())
+	if err != nil {
+		h.log.Info("MFA Verify Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
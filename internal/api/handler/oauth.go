@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type OAuthHandler struct {
+	oauthService service.OAuthService
+	log          *zap.Logger
+}
+
+func NewOAuthHandler(oauthService service.OAuthService, logger *zap.Logger) *OAuthHandler {
+	return &OAuthHandler{
+		oauthService: oauthService,
+		log:          logger,
+	}
+}
+
+// Start перенаправляет пользователя на страницу авторизации провайдера
+func (h *OAuthHandler) Start(c *gin.Context) {
+	provider := c.Param("provider")
+
+	authURL, err := h.oauthService.StartAuth(c.Request.Context(), provider)
+	if err != nil {
+		h.log.Info("OAuth Start Error", zap.String("provider", provider), zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	provider := c.Param("provider")
+
+	// Провайдер возвращает error, если пользователь отказался от авторизации
+	if providerErr := c.Query("error"); providerErr != "" {
+		h.log.Info("OAuth Provider Error", zap.String("provider", provider), zap.String("error", providerErr))
+		_ = c.Error(service.ErrOAuthDenied)
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		_ = c.Error(service.ErrInvalidRequest)
+		return
+	}
+
+	resp, err := h.oauthService.HandleCallback(c.Request.Context(), provider, state, code)
+	if err != nil {
+		h.log.Info("OAuth Callback Error", zap.String("provider", provider), zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
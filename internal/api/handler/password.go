@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type PasswordHandler struct {
+	passwordResetService service.PasswordResetService
+	log                  *zap.Logger
+}
+
+func NewPasswordHandler(passwordResetService service.PasswordResetService, logger *zap.Logger) *PasswordHandler {
+	return &PasswordHandler{
+		passwordResetService: passwordResetService,
+		log:                  logger,
+	}
+}
+
+// Forgot всегда отвечает 202, чтобы нельзя было узнать, зарегистрирован ли email
+func (h *PasswordHandler) Forgot(c *gin.Context) {
+	var req apimodel.PasswordForgot
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	if err := h.passwordResetService.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		h.log.Error("Forgot Password Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *PasswordHandler) Reset(c *gin.Context) {
+	var req apimodel.PasswordReset
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		h.log.Info("Reset Password Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
 	"backend_go/internal/model/entitymodel"
 	"backend_go/internal/service"
+	"context"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"net/http"
 )
@@ -21,31 +26,435 @@ func NewSessionHandler(sessionService service.SessionService, log *zap.Logger) *
 }
 
 func (h *SessionHandler) GetUserSession(c *gin.Context) {
-	userInterface, exists := c.Get("user")
-	if !exists {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "User not found in context"})
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var query apimodel.SessionListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	page, err := h.sessionService.GetUserSession(c.Request.Context(), user.ID.String(), &query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *SessionHandler) CreateSession(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	session, err := h.sessionService.CreateSession(c.Request.Context(), user, &req)
+	if err != nil {
+		h.log.Info("Create Session Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.SessionEntityToAPI(session))
+}
+
+func (h *SessionHandler) CloneSession(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionClone
+	// Тело необязательно: без него копия получает то же название и пустой список задач
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Info("Bind Error", zap.Error(err))
+			_ = c.Error(validation.ToServiceError(err))
+			return
+		}
+	}
+
+	session, err := h.sessionService.CloneSession(c.Request.Context(), user, participant, &req)
+	if err != nil {
+		h.log.Info("Clone Session Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.SessionEntityToAPI(session))
+}
+
+func (h *SessionHandler) GetSession(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.GetSession(c.Request.Context(), participant.SessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionEntityToAPI(session))
+}
+
+func (h *SessionHandler) Join(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	sessionID := c.Param("id")
+	if _, err := uuid.Parse(sessionID); err != nil {
+		_ = c.Error(service.ErrSessionNotFound)
+		return
+	}
+
+	var req apimodel.SessionJoin
+	// Тело необязательно: без него пользователь входит голосующим
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Info("Bind Error", zap.Error(err))
+			_ = c.Error(validation.ToServiceError(err))
+			return
+		}
+	}
+
+	participant, err := h.sessionService.Join(c.Request.Context(), user, sessionID, &req, c.ClientIP())
+	if err != nil {
+		h.log.Info("Join Session Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionParticipantEntityToAPI(participant))
+}
+
+func (h *SessionHandler) JoinByCode(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionJoinByCode
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	participant, err := h.sessionService.JoinByCode(c.Request.Context(), user, &req, c.ClientIP())
+	if err != nil {
+		h.log.Info("Join Session By Code Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionParticipantEntityToAPI(participant))
+}
+
+func (h *SessionHandler) RegenerateJoinCode(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.RegenerateJoinCode(c.Request.Context(), participant)
+	if err != nil {
+		h.log.Info("Regenerate Join Code Error", zap.Error(err))
+		_ = c.Error(err)
 		return
 	}
 
-	user, ok := userInterface.(*entitymodel.User)
+	c.JSON(http.StatusOK, converter.SessionEntityToAPI(session))
+}
+
+func (h *SessionHandler) UpdateAccess(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionAccessUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	session, err := h.sessionService.UpdateAccess(c.Request.Context(), participant, &req)
+	if err != nil {
+		h.log.Info("Update Session Access Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionEntityToAPI(session))
+}
+
+func (h *SessionHandler) ListParticipants(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	participants, err := h.sessionService.ListParticipants(c.Request.Context(), participant.SessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := make([]*apimodel.SessionParticipant, 0, len(participants))
+	for _, p := range participants {
+		resp = append(resp, converter.SessionParticipantEntityToAPI(p))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SessionHandler) SetParticipantRole(c *gin.Context) {
+	participant, ok := currentParticipant(c)
 	if !ok {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
 		return
 	}
 
-	if user.IsGuest {
-		c.AbortWithStatusJSON(
-			http.StatusForbidden,
-			gin.H{"error": "Гостевые пользователи не могут просматривать свои сессии."},
-		)
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		_ = c.Error(service.ErrParticipantNotFound)
+		return
+	}
+
+	var req apimodel.SessionRoleUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	updated, err := h.sessionService.SetParticipantRole(c.Request.Context(), participant, userID, req.Role)
+	if err != nil {
+		h.log.Info("Set Participant Role Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionParticipantEntityToAPI(updated))
+}
+
+func (h *SessionHandler) TransferFacilitator(c *gin.Context) {
+	h.changeParticipant(c, "Transfer Facilitator Error", h.sessionService.TransferFacilitator)
+}
+
+func (h *SessionHandler) AddCoFacilitator(c *gin.Context) {
+	h.changeParticipant(c, "Add Co-Facilitator Error", h.sessionService.AddCoFacilitator)
+}
+
+func (h *SessionHandler) RemoveCoFacilitator(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		_ = c.Error(service.ErrParticipantNotFound)
+		return
+	}
+
+	updated, err := h.sessionService.RemoveCoFacilitator(c.Request.Context(), participant, userID)
+	if err != nil {
+		h.log.Info("Remove Co-Facilitator Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionParticipantEntityToAPI(updated))
+}
+
+func (h *SessionHandler) Kick(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		_ = c.Error(service.ErrParticipantNotFound)
+		return
+	}
+
+	if err := h.sessionService.Kick(c.Request.Context(), participant, userID); err != nil {
+		h.log.Info("Kick Participant Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SessionHandler) ListBans(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	bans, err := h.sessionService.ListBans(c.Request.Context(), participant.SessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := make([]*apimodel.SessionBan, 0, len(bans))
+	for _, ban := range bans {
+		resp = append(resp, converter.SessionBanEntityToAPI(ban))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SessionHandler) Ban(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionBanCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	ban, err := h.sessionService.Ban(c.Request.Context(), participant, &req)
+	if err != nil {
+		h.log.Info("Ban Participant Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.SessionBanEntityToAPI(ban))
+}
+
+func (h *SessionHandler) Unban(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		_ = c.Error(service.ErrSessionBanNotFound)
+		return
+	}
+
+	if err := h.sessionService.Unban(c.Request.Context(), participant, userID); err != nil {
+		h.log.Info("Unban Participant Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SessionHandler) Archive(c *gin.Context) {
+	h.updateSession(c, "Archive Session Error", h.sessionService.Archive)
+}
+
+func (h *SessionHandler) Unarchive(c *gin.Context) {
+	h.updateSession(c, "Unarchive Session Error", h.sessionService.Unarchive)
+}
+
+func (h *SessionHandler) RevealCards(c *gin.Context) {
+	h.updateSession(c, "Reveal Cards Error", h.sessionService.RevealCards)
+}
+
+func (h *SessionHandler) ResetVotes(c *gin.Context) {
+	h.updateSession(c, "Reset Votes Error", h.sessionService.ResetVotes)
+}
+
+func (h *SessionHandler) ListEvents(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.sessionService.ListEvents(c.Request.Context(), participant.SessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := make([]*apimodel.SessionEvent, 0, len(events))
+	for _, event := range events {
+		resp = append(resp, converter.SessionEventEntityToAPI(event))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// changeParticipant - общий обработчик действий над участником, переданным в теле запроса
+func (h *SessionHandler) changeParticipant(
+	c *gin.Context,
+	logMessage string,
+	action func(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) (*entitymodel.SessionParticipant, error),
+) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionParticipantRef
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	updated, err := action(c.Request.Context(), participant, req.UserID)
+	if err != nil {
+		h.log.Info(logMessage, zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionParticipantEntityToAPI(updated))
+}
+
+// updateSession - общий обработчик действий ведущего над сессией без тела запроса
+// (вскрытие и сброс карт, архивирование)
+func (h *SessionHandler) updateSession(
+	c *gin.Context,
+	logMessage string,
+	action func(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error),
+) {
+	participant, ok := currentParticipant(c)
+	if !ok {
 		return
 	}
 
-	sessions, err := h.sessionService.GetUserSession(c.Request.Context(), user.ID.String())
+	session, err := action(c.Request.Context(), participant)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error getting user sessions"})
+		h.log.Info(logMessage, zap.Error(err))
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, sessions)
+	c.JSON(http.StatusOK, converter.SessionEntityToAPI(session))
 }
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type TemplateHandler struct {
+	templateService service.TemplateService
+	log             *zap.Logger
+}
+
+func NewTemplateHandler(templateService service.TemplateService, logger *zap.Logger) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+		log:             logger,
+	}
+}
+
+func (h *TemplateHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.templateService.List(c.Request.Context(), user)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := make([]*apimodel.SessionTemplate, 0, len(templates))
+	for _, template := range templates {
+		resp = append(resp, converter.SessionTemplateEntityToAPI(template))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) Create(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionTemplateSave
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	template, err := h.templateService.Create(c.Request.Context(), user, &req)
+	if err != nil {
+		h.log.Info("Create Template Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.SessionTemplateEntityToAPI(template))
+}
+
+func (h *TemplateHandler) Update(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		_ = c.Error(service.ErrSessionTemplateNotFound)
+		return
+	}
+
+	var req apimodel.SessionTemplateSave
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	template, err := h.templateService.Update(c.Request.Context(), user, id, &req)
+	if err != nil {
+		h.log.Info("Update Template Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionTemplateEntityToAPI(template))
+}
+
+func (h *TemplateHandler) Delete(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		_ = c.Error(service.ErrSessionTemplateNotFound)
+		return
+	}
+
+	if err := h.templateService.Delete(c.Request.Context(), user, id); err != nil {
+		h.log.Info("Delete Template Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
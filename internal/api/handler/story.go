@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"backend_go/internal/api/validation"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type StoryHandler struct {
+	storyService service.StoryService
+	log          *zap.Logger
+}
+
+func NewStoryHandler(storyService service.StoryService, logger *zap.Logger) *StoryHandler {
+	return &StoryHandler{
+		storyService: storyService,
+		log:          logger,
+	}
+}
+
+func (h *StoryHandler) List(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	stories, err := h.storyService.List(c.Request.Context(), participant.SessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := make([]*apimodel.SessionStory, 0, len(stories))
+	for _, story := range stories {
+		resp = append(resp, converter.SessionStoryEntityToAPI(story))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *StoryHandler) Create(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	var req apimodel.SessionStoryCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	story, err := h.storyService.Create(c.Request.Context(), participant, &req)
+	if err != nil {
+		h.log.Info("Create Story Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.SessionStoryEntityToAPI(story))
+}
+
+func (h *StoryHandler) SetEstimate(c *gin.Context) {
+	participant, ok := currentParticipant(c)
+	if !ok {
+		return
+	}
+
+	storyID := c.Param("story_id")
+	if _, err := uuid.Parse(storyID); err != nil {
+		_ = c.Error(service.ErrSessionStoryNotFound)
+		return
+	}
+
+	var req apimodel.SessionStoryEstimate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Info("Bind Error", zap.Error(err))
+		_ = c.Error(validation.ToServiceError(err))
+		return
+	}
+
+	story, err := h.storyService.SetEstimate(c.Request.Context(), participant, storyID, req.Estimate)
+	if err != nil {
+		h.log.Info("Set Story Estimate Error", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.SessionStoryEntityToAPI(story))
+}
@@ -1,31 +1,49 @@
 package middleware
 
 import (
+	"backend_go/internal/model/entitymodel"
 	"backend_go/internal/service"
 	"github.com/gin-gonic/gin"
-	"net/http"
 	"strings"
 )
 
-func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
+// apiTokenContextKey - ключ контекста, под которым лежит персональный токен запроса.
+// Для запросов с JWT ключ не заполняется.
+const apiTokenContextKey = "api_token"
+
+// AuthMiddleware принимает как JWT, так и персональные токены (по префиксу entitymodel.APITokenPrefix)
+func AuthMiddleware(authService service.AuthService, apiTokenService service.APITokenService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			abortWithError(c, service.ErrUnauthorized)
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+			abortWithError(c, service.ErrInvalidToken)
 			return
 		}
 
 		token := parts[1]
 
+		if strings.HasPrefix(token, entitymodel.APITokenPrefix) {
+			user, apiToken, err := apiTokenService.Authenticate(c.Request.Context(), token)
+			if err != nil {
+				abortWithError(c, err)
+				return
+			}
+
+			c.Set("user", user)
+			c.Set(apiTokenContextKey, apiToken)
+			c.Next()
+			return
+		}
+
 		user, err := authService.ValidateToken(c.Request.Context(), token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			abortWithError(c, err)
 			return
 		}
 
@@ -33,3 +51,55 @@ func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware пропускает анонимные запросы, а запросы с заголовком Authorization
+// проверяет так же, как AuthMiddleware: неверный токен не превращает запрос в анонимный
+func OptionalAuthMiddleware(authService service.AuthService, apiTokenService service.APITokenService) gin.HandlerFunc {
+	auth := AuthMiddleware(authService, apiTokenService)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
+
+// RequireScope пропускает запросы с персональным токеном, только если у него есть scope.
+// Запросы с JWT проходят без ограничений. Ставится после AuthMiddleware.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiToken, ok := currentAPIToken(c); ok && !apiToken.HasScope(scope) {
+			abortWithError(c, service.ErrInsufficientScope)
+			return
+		}
+		c.Next()
+	}
+}
+
+// DenyAPITokens закрывает маршрут для персональных токенов: управление аккаунтом,
+// паролем и самими токенами доступно только после входа пользователя
+func DenyAPITokens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := currentAPIToken(c); ok {
+			abortWithError(c, service.ErrAPITokenNotAllowed)
+			return
+		}
+		c.Next()
+	}
+}
+
+func currentAPIToken(c *gin.Context) (*entitymodel.APIToken, bool) {
+	value, exists := c.Get(apiTokenContextKey)
+	if !exists {
+		return nil, false
+	}
+	apiToken, ok := value.(*entitymodel.APIToken)
+	return apiToken, ok
+}
+
+// abortWithError прерывает цепочку обработчиков; ответ сформирует ErrorHandler
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
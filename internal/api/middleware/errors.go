@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+var errInternal = service.NewError(0, "internal_error", "internal server error")
+
+// ErrorHandler превращает ошибки, добавленные обработчиками через c.Error, в ответ единого формата.
+// Неизвестные ошибки логируются и отдаются клиенту как internal_error без подробностей.
+func ErrorHandler(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+
+		var domainErr *service.Error
+		if !errors.As(err, &domainErr) {
+			log.Error("unhandled error",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.FullPath()),
+				zap.Error(err),
+			)
+			domainErr = errInternal
+		}
+
+		if domainErr.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(domainErr.RetryAfter)))
+		}
+
+		c.AbortWithStatusJSON(statusForKind(domainErr.Kind), toErrorResponse(domainErr))
+	}
+}
+
+// NoRoute отвечает на запросы к несуществующим маршрутам в едином формате
+func NoRoute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = c.Error(service.ErrNotFound)
+	}
+}
+
+func statusForKind(kind service.ErrorKind) int {
+	switch kind {
+	case service.KindInvalid:
+		return http.StatusBadRequest
+	case service.KindUnauthorized:
+		return http.StatusUnauthorized
+	case service.KindForbidden:
+		return http.StatusForbidden
+	case service.KindNotFound:
+		return http.StatusNotFound
+	case service.KindConflict:
+		return http.StatusConflict
+	case service.KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case service.KindTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toErrorResponse(err *service.Error) apimodel.ErrorResponse {
+	resp := apimodel.ErrorResponse{
+		Error: apimodel.ErrorBody{
+			Code:    err.Code,
+			Message: err.Message,
+		},
+	}
+
+	for _, field := range err.Fields {
+		resp.Error.Fields = append(resp.Error.Fields, apimodel.FieldError{
+			Field:   field.Field,
+			Code:    field.Code,
+			Message: field.Message,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"backend_go/internal/infrastructure/ratelimit"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"math"
+	"strconv"
+	"time"
+)
+
+// RateLimit ограничивает частоту запросов к группе маршрутов по алгоритму token bucket.
+// Ключ - id пользователя, если запрос уже прошёл AuthMiddleware, иначе IP клиента.
+// Ответ содержит заголовки RateLimit-Limit/Remaining/Reset; при сбое хранилища запрос пропускается.
+func RateLimit(limiter ratelimit.Limiter, group string, limit ratelimit.Limit, log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		key := group + ":" + rateLimitSubject(c)
+
+		result, err := limiter.Allow(c.Request.Context(), key, limit)
+		if err != nil {
+			log.Error("rate limiter failed", zap.String("group", group), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			log.Info("rate limit exceeded", zap.String("group", group), zap.String("key", key))
+			abortWithError(c, service.ErrRateLimited.WithRetryAfter(result.RetryAfter))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitSubject - владелец ведра. IP берётся через ClientIP, который учитывает
+// X-Forwarded-For только от доверенных прокси (TRUSTED_PROXIES), иначе ключ можно подменить заголовком.
+func rateLimitSubject(c *gin.Context) string {
+	if user, ok := contextUser(c); ok {
+		return "user:" + user.ID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
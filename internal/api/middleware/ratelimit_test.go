@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"backend_go/internal/infrastructure/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimitClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   []string
+		wantStatus     []int
+	}{
+		{
+			name:         "forwarded header ignored without trusted proxies",
+			remoteAddr:   "203.0.113.10:40000",
+			forwardedFor: []string{"198.51.100.1", "198.51.100.2"},
+			wantStatus:   []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:           "forwarded header from untrusted client ignored",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.10:40000",
+			forwardedFor:   []string{"198.51.100.1", "198.51.100.2"},
+			wantStatus:     []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:           "forwarded header from trusted proxy identifies clients",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.5:40000",
+			forwardedFor:   []string{"198.51.100.1", "198.51.100.2"},
+			wantStatus:     []int{http.StatusOK, http.StatusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			if err := router.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatalf("SetTrustedProxies() error = %v", err)
+			}
+			router.Use(ErrorHandler(zap.NewNop()))
+			router.Use(RateLimit(ratelimit.NewMemoryLimiter(), "global", ratelimit.PerMinute(1), zap.NewNop()))
+			router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			for i, forwardedFor := range tt.forwardedFor {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = tt.remoteAddr
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				if rec.Code != tt.wantStatus[i] {
+					t.Errorf("request %d: status = %d, want %d", i+1, rec.Code, tt.wantStatus[i])
+				}
+			}
+		})
+	}
+}
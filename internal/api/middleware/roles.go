@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// sessionParticipantContextKey - ключ контекста с участником сессии, которого нашёл RequireSessionRole
+const sessionParticipantContextKey = "session_participant"
+
+// RequireRole пропускает пользователей с одной из глобальных ролей. Ставится после AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := contextUser(c)
+		if !ok {
+			abortWithError(c, service.ErrUnauthorized)
+			return
+		}
+
+		if !user.HasRole(roles...) {
+			if user.IsGuest {
+				abortWithError(c, service.ErrGuestForbidden)
+				return
+			}
+			abortWithError(c, service.ErrForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSessionRole пропускает участников сессии :id с одной из ролей и кладёт участника
+// в контекст для обработчика. Ставится после AuthMiddleware.
+func RequireSessionRole(sessionService service.SessionService, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := contextUser(c)
+		if !ok {
+			abortWithError(c, service.ErrUnauthorized)
+			return
+		}
+
+		sessionID := c.Param("id")
+		if _, err := uuid.Parse(sessionID); err != nil {
+			abortWithError(c, service.ErrSessionNotFound)
+			return
+		}
+
+		participant, err := sessionService.GetParticipant(c.Request.Context(), sessionID, user.ID.String())
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		if !participant.HasRole(roles...) {
+			abortWithError(c, service.ErrInsufficientSessionRole)
+			return
+		}
+
+		c.Set(sessionParticipantContextKey, participant)
+		c.Next()
+	}
+}
+
+func contextUser(c *gin.Context) (*entitymodel.User, bool) {
+	value, exists := c.Get("user")
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(*entitymodel.User)
+	return user, ok
+}
@@ -0,0 +1,194 @@
+package validation
+
+import (
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/service"
+	"backend_go/pkg/password"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	NameMaxLength       = 64
+	StoryTitleMaxLength = 256
+)
+
+// Register подключает пользовательские правила к валидатору gin.
+// Вызывается один раз при старте сервера, до регистрации маршрутов.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unexpected gin validator engine")
+	}
+
+	// В ошибках полей используем имена из json-тегов
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return strings.ToLower(field.Name)
+		}
+		return name
+	})
+
+	rules := map[string]validator.Func{
+		"password":     validatePassword,
+		"name":         validateName,
+		"deck_type":    validateDeckType,
+		"vote_value":   validateVoteValue,
+		"api_scope":    validateAPIScope,
+		"user_role":    validateUserRole,
+		"session_role": validateSessionRole,
+		"story_title":  validateStoryTitle,
+	}
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return fmt.Errorf("failed to register %s validation: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// validatePassword отсекает заведомо неподходящие пароли; полная политика проверяется в PasswordService
+func validatePassword(fl validator.FieldLevel) bool {
+	return len(fl.Field().String()) <= password.BcryptMaxLength
+}
+
+func validateName(fl validator.FieldLevel) bool {
+	name := strings.TrimSpace(fl.Field().String())
+	return name != "" && utf8.RuneCountInString(name) <= NameMaxLength
+}
+
+func validateDeckType(fl validator.FieldLevel) bool {
+	return entitymodel.IsValidDeckType(fl.Field().String())
+}
+
+func validateVoteValue(fl validator.FieldLevel) bool {
+	return entitymodel.IsKnownVoteValue(fl.Field().String())
+}
+
+func validateAPIScope(fl validator.FieldLevel) bool {
+	return entitymodel.IsValidAPITokenScope(fl.Field().String())
+}
+
+func validateUserRole(fl validator.FieldLevel) bool {
+	return entitymodel.IsValidRole(fl.Field().String())
+}
+
+func validateSessionRole(fl validator.FieldLevel) bool {
+	return entitymodel.IsValidSessionRole(fl.Field().String())
+}
+
+func validateStoryTitle(fl validator.FieldLevel) bool {
+	title := strings.TrimSpace(fl.Field().String())
+	return title != "" && utf8.RuneCountInString(title) <= StoryTitleMaxLength
+}
+
+// ToServiceError превращает ошибку биндинга в доменную ошибку:
+// нарушения правил валидации - в ErrValidation с ошибками полей, остальное - в ErrInvalidRequest
+func ToServiceError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return service.ErrInvalidRequest
+	}
+
+	fields := make([]service.FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fields = append(fields, service.FieldError{
+			Field:   fieldErr.Field(),
+			Code:    fieldErr.Tag(),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+
+	return service.ErrValidation.WithFields(fields...)
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "field is required"
+	case "email":
+		return "must be a valid email address"
+	case "max":
+		if isCollection(fieldErr.Kind()) {
+			return fmt.Sprintf("must contain at most %s items", fieldErr.Param())
+		}
+		if isNumber(fieldErr.Kind()) {
+			return fmt.Sprintf("must be at most %s", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at most %s characters long", fieldErr.Param())
+	case "min":
+		if isCollection(fieldErr.Kind()) {
+			return fmt.Sprintf("must contain at least %s items", fieldErr.Param())
+		}
+		if isNumber(fieldErr.Kind()) {
+			return fmt.Sprintf("must be at least %s", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at least %s characters long", fieldErr.Param())
+	case "password":
+		return fmt.Sprintf("must be at most %d bytes long", password.BcryptMaxLength)
+	case "name":
+		return fmt.Sprintf("must not be blank and at most %d characters long", NameMaxLength)
+	case "deck_type":
+		return fmt.Sprintf("must be one of: %s, %s, %s, %s",
+			entitymodel.DeckTypeFibonacci,
+			entitymodel.DeckTypeModifiedFibonacci,
+			entitymodel.DeckTypeTShirt,
+			entitymodel.DeckTypePowersOfTwo,
+		)
+	case "vote_value":
+		return "must be a card from the session deck"
+	case "api_scope":
+		return "must be one of: " + strings.Join(entitymodel.APITokenScopes(), ", ")
+	case "user_role":
+		return fmt.Sprintf("must be one of: %s, %s, %s",
+			entitymodel.RoleAdmin,
+			entitymodel.RoleUser,
+			entitymodel.RoleGuest,
+		)
+	case "session_role":
+		return fmt.Sprintf("must be one of: %s, %s, %s, %s",
+			entitymodel.SessionRoleFacilitator,
+			entitymodel.SessionRoleCoFacilitator,
+			entitymodel.SessionRoleVoter,
+			entitymodel.SessionRoleObserver,
+		)
+	case "uuid":
+		return "must be a valid uuid"
+	case "story_title":
+		return fmt.Sprintf("must not be blank and at most %d characters long", StoryTitleMaxLength)
+	case "required_without":
+		return "field is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "fqdn":
+		return "must be a valid domain name"
+	default:
+		return "is invalid"
+	}
+}
+
+func isCollection(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import "time"
+
+// LoginProtectionConfig - пороги защиты входа от перебора паролей
+type LoginProtectionConfig struct {
+	DelayAfter      int // неудач по email до первой задержки
+	LockoutAfter    int // неудач по email до блокировки
+	IPLockoutAfter  int // неудач с одного IP до блокировки
+	MaxDelay        int // в секундах
+	LockoutDuration int // в минутах
+	Window          int // в минутах, время жизни счётчика неудач
+	// Коды-пароли сессий: блокировка по пользователю в сессии и по IP, длительность и окно общие со входом
+	PasscodeLockoutAfter   int
+	PasscodeIPLockoutAfter int
+}
+
+func loadLoginProtectionConfig() LoginProtectionConfig {
+	return LoginProtectionConfig{
+		DelayAfter:      getEnvAsInt("LOGIN_DELAY_AFTER", 3),
+		LockoutAfter:    getEnvAsInt("LOGIN_LOCKOUT_AFTER", 10),
+		IPLockoutAfter:  getEnvAsInt("LOGIN_IP_LOCKOUT_AFTER", 50),
+		MaxDelay:        getEnvAsInt("LOGIN_MAX_DELAY", 60),
+		LockoutDuration: getEnvAsInt("LOGIN_LOCKOUT_DURATION", 15),
+		Window:          getEnvAsInt("LOGIN_ATTEMPT_WINDOW", 15),
+
+		PasscodeLockoutAfter:   getEnvAsInt("SESSION_PASSCODE_LOCKOUT_AFTER", 5),
+		PasscodeIPLockoutAfter: getEnvAsInt("SESSION_PASSCODE_IP_LOCKOUT_AFTER", 20),
+	}
+}
+
+func (c LoginProtectionConfig) GetMaxDelay() time.Duration {
+	return time.Duration(c.MaxDelay) * time.Second
+}
+
+func (c LoginProtectionConfig) GetLockoutDuration() time.Duration {
+	return time.Duration(c.LockoutDuration) * time.Minute
+}
+
+func (c LoginProtectionConfig) GetWindow() time.Duration {
+	return time.Duration(c.Window) * time.Minute
+}
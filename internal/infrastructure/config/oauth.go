@@ -0,0 +1,42 @@
+package config
+
+// OAuthProviderConfig - настройки OAuth2-провайдера.
+// Эндпоинты вынесены в конфиг, чтобы флоу можно было проверить на локальном фейковом провайдере.
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+}
+
+// Enabled возвращает true, если провайдер настроен
+func (c OAuthProviderConfig) Enabled() bool {
+	return c.ClientID != ""
+}
+
+func loadGoogleOAuthConfig() OAuthProviderConfig {
+	return OAuthProviderConfig{
+		ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
+		ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+		RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/auth/oauth/google/callback"),
+		AuthURL:      getEnv("GOOGLE_AUTH_URL", "https://accounts.google.com/o/oauth2/v2/auth"),
+		TokenURL:     getEnv("GOOGLE_TOKEN_URL", "https://oauth2.googleapis.com/token"),
+		UserInfoURL:  getEnv("GOOGLE_USERINFO_URL", "https://openidconnect.googleapis.com/v1/userinfo"),
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+}
+
+func loadYandexOAuthConfig() OAuthProviderConfig {
+	return OAuthProviderConfig{
+		ClientID:     getEnv("YANDEX_CLIENT_ID", ""),
+		ClientSecret: getEnv("YANDEX_CLIENT_SECRET", ""),
+		RedirectURL:  getEnv("YANDEX_REDIRECT_URL", "http://localhost:8080/api/auth/oauth/yandex/callback"),
+		AuthURL:      getEnv("YANDEX_AUTH_URL", "https://oauth.yandex.ru/authorize"),
+		TokenURL:     getEnv("YANDEX_TOKEN_URL", "https://oauth.yandex.ru/token"),
+		UserInfoURL:  getEnv("YANDEX_USERINFO_URL", "https://login.yandex.ru/info?format=json"),
+		Scopes:       []string{"login:email", "login:info", "login:avatar"},
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OIDCProviderConfig - настройки OpenID Connect провайдера (например, Keycloak).
+// Эндпоинты получаются через discovery по IssuerURL.
+type OIDCProviderConfig struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// loadOIDCProvidersConfig читает список провайдеров из OIDC_PROVIDERS (через запятую),
+// а настройки каждого - из переменных OIDC_<NAME>_*
+func loadOIDCProvidersConfig() []OIDCProviderConfig {
+	names := splitList(getEnv("OIDC_PROVIDERS", ""))
+	providers := make([]OIDCProviderConfig, 0, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			IssuerURL:    getEnv(prefix+"ISSUER_URL", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL: getEnv(
+				prefix+"REDIRECT_URL",
+				fmt.Sprintf("http://localhost:8080/api/auth/oauth/%s/callback", name),
+			),
+			Scopes: splitList(getEnv(prefix+"SCOPES", "openid,email,profile")),
+		})
+	}
+
+	return providers
+}
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
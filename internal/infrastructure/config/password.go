@@ -0,0 +1,25 @@
+package config
+
+// PasswordPolicyConfig - требования к паролям пользователей
+type PasswordPolicyConfig struct {
+	MinLength     int
+	MaxLength     int // в байтах, bcrypt учитывает не больше 72
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// BreachedListPath - файл с SHA-1 хешами утёкших паролей, пустое значение отключает проверку
+	BreachedListPath string
+}
+
+func loadPasswordPolicyConfig() PasswordPolicyConfig {
+	return PasswordPolicyConfig{
+		MinLength:        getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+		MaxLength:        getEnvAsInt("PASSWORD_MAX_LENGTH", 72),
+		RequireUpper:     getEnvAsBool("PASSWORD_REQUIRE_UPPER", true),
+		RequireLower:     getEnvAsBool("PASSWORD_REQUIRE_LOWER", true),
+		RequireDigit:     getEnvAsBool("PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol:    getEnvAsBool("PASSWORD_REQUIRE_SYMBOL", false),
+		BreachedListPath: getEnv("PASSWORD_BREACHED_LIST", ""),
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+// RateLimitConfig - лимиты запросов в минуту для групп маршрутов.
+// 0 отключает лимит группы; по умолчанию используется общий RATE_LIMIT.
+type RateLimitConfig struct {
+	Global    int
+	Auth      int
+	Sessions  int
+	Votes     int
+	Reactions int
+}
+
+func loadRateLimitConfig() RateLimitConfig {
+	global := getEnvAsInt("RATE_LIMIT", 1000)
+
+	return RateLimitConfig{
+		Global:    global,
+		Auth:      getEnvAsInt("RATE_LIMIT_AUTH", global),
+		Sessions:  getEnvAsInt("RATE_LIMIT_SESSIONS", global),
+		Votes:     getEnvAsInt("RATE_LIMIT_VOTES", global),
+		Reactions: getEnvAsInt("RATE_LIMIT_REACTIONS", global),
+	}
+}
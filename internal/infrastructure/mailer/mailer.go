@@ -0,0 +1,15 @@
+package mailer
+
+import "context"
+
+// Message - письмо в виде простого текста
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer - отправка писем пользователям
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
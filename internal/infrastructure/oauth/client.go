@@ -0,0 +1,113 @@
+package oauth
+
+import (
+	"backend_go/internal/infrastructure/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// client - общая часть authorization code флоу, одинаковая для всех провайдеров
+type client struct {
+	cfg        config.OAuthProviderConfig
+	httpClient *http.Client
+}
+
+func newClient(cfg config.OAuthProviderConfig) client {
+	return client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+func (c client) AuthCodeURL(state, codeChallenge, nonce string) string {
+	return c.authCodeURL(state, codeChallenge, nil)
+}
+
+// authCodeURL собирает URL авторизации; extra - дополнительные параметры провайдера
+func (c client) authCodeURL(state, codeChallenge string, extra url.Values) string {
+	params := url.Values{}
+	for key, values := range extra {
+		params[key] = values
+	}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.cfg.ClientID)
+	params.Set("redirect_uri", c.cfg.RedirectURL)
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+	if len(c.cfg.Scopes) > 0 {
+		params.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	}
+
+	separator := "?"
+	if strings.Contains(c.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+
+	return c.cfg.AuthURL + separator + params.Encode()
+}
+
+func (c client) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.cfg.RedirectURL)
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("client_secret", c.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token Token
+	if err := c.doJSON(req, &token); err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, ErrEmptyAccessToken
+	}
+
+	return &token, nil
+}
+
+// getJSON запрашивает url с заголовком Authorization и декодирует ответ в dest
+func (c client) getJSON(ctx context.Context, rawURL, authorization string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return c.doJSON(req, dest)
+}
+
+func (c client) doJSON(req *http.Request, dest interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, req.URL.Host, string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
@@ -0,0 +1,42 @@
+package oauth
+
+import (
+	"backend_go/internal/infrastructure/config"
+	"context"
+	"fmt"
+)
+
+type googleProvider struct {
+	client
+}
+
+func NewGoogleProvider(cfg config.OAuthProviderConfig) Provider {
+	return &googleProvider{client: newClient(cfg)}
+}
+
+func (p *googleProvider) Name() string {
+	return "google"
+}
+
+type googleUserInfo struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
+
+func (p *googleProvider) UserInfo(ctx context.Context, token *Token, nonce string) (*UserInfo, error) {
+	var info googleUserInfo
+	if err := p.getJSON(ctx, p.cfg.UserInfoURL, "Bearer "+token.AccessToken, &info); err != nil {
+		return nil, fmt.Errorf("failed to fetch google user info: %w", err)
+	}
+
+	return &UserInfo{
+		ID:            info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          info.Name,
+		AvatarURL:     info.Picture,
+	}, nil
+}
@@ -0,0 +1,313 @@
+package oauth
+
+import (
+	"backend_go/internal/infrastructure/config"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// jwksRefreshInterval - не чаще этого интервала перечитываем JWKS при встрече неизвестного kid
+	jwksRefreshInterval = time.Minute
+)
+
+var (
+	ErrMissingIDToken    = errors.New("oidc provider did not return id_token")
+	ErrInvalidIDToken    = errors.New("invalid id_token")
+	ErrUnknownSigningKey = errors.New("unknown id_token signing key")
+)
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type idTokenClaims struct {
+	Nonce             string `json:"nonce"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Picture           string `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// oidcProvider - универсальный OpenID Connect провайдер (Keycloak и т.п.)
+type oidcProvider struct {
+	client
+	name    string
+	issuer  string
+	jwksURL string
+
+	mu            sync.RWMutex
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewOIDCProvider получает эндпоинты провайдера через discovery и загружает JWKS
+func NewOIDCProvider(ctx context.Context, cfg config.OIDCProviderConfig) (Provider, error) {
+	issuer := strings.TrimSuffix(cfg.IssuerURL, "/")
+	if issuer == "" {
+		return nil, fmt.Errorf("oidc provider %s: issuer url is required", cfg.Name)
+	}
+
+	p := &oidcProvider{
+		client: newClient(config.OAuthProviderConfig{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+		}),
+		name:   cfg.Name,
+		issuer: issuer,
+	}
+
+	var doc discoveryDocument
+	if err := p.getJSON(ctx, issuer+discoveryPath, "", &doc); err != nil {
+		return nil, fmt.Errorf("oidc provider %s: discovery failed: %w", cfg.Name, err)
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc provider %s: issuer mismatch: expected %s, got %s", cfg.Name, issuer, doc.Issuer)
+	}
+
+	p.cfg.AuthURL = doc.AuthorizationEndpoint
+	p.cfg.TokenURL = doc.TokenEndpoint
+	p.cfg.UserInfoURL = doc.UserInfoEndpoint
+	p.issuer = doc.Issuer
+	p.jwksURL = doc.JWKSURI
+
+	if err := p.refreshKeys(ctx); err != nil {
+		return nil, fmt.Errorf("oidc provider %s: %w", cfg.Name, err)
+	}
+
+	return p, nil
+}
+
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+func (p *oidcProvider) AuthCodeURL(state, codeChallenge, nonce string) string {
+	extra := url.Values{}
+	if nonce != "" {
+		extra.Set("nonce", nonce)
+	}
+	return p.authCodeURL(state, codeChallenge, extra)
+}
+
+// UserInfo проверяет ID-токен и приводит его claims к UserInfo.
+// Если в ID-токене нет email, данные дополняются из userinfo-эндпоинта.
+func (p *oidcProvider) UserInfo(ctx context.Context, token *Token, nonce string) (*UserInfo, error) {
+	if token.IDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	claims, err := p.verifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Email == "" && p.cfg.UserInfoURL != "" {
+		var extra idTokenClaims
+		if err := p.getJSON(ctx, p.cfg.UserInfoURL, "Bearer "+token.AccessToken, &extra); err != nil {
+			return nil, fmt.Errorf("failed to fetch oidc user info: %w", err)
+		}
+		// userinfo обязан относиться к тому же субъекту, что и ID-токен
+		if extra.Subject == claims.Subject {
+			claims.Email = extra.Email
+			claims.EmailVerified = extra.EmailVerified
+			if claims.Name == "" {
+				claims.Name = extra.Name
+			}
+			if claims.Picture == "" {
+				claims.Picture = extra.Picture
+			}
+		}
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+
+	return &UserInfo{
+		ID:            claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          name,
+		AvatarURL:     claims.Picture,
+	}, nil
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, rawToken, nonce string) (*idTokenClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{
+		"RS256", "RS384", "RS512", "ES256", "ES384", "ES512",
+	}))
+
+	claims := &idTokenClaims{}
+	_, err := parser.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.signingKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if !claims.VerifyIssuer(p.issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer %s", ErrInvalidIDToken, claims.Issuer)
+	}
+	if !claims.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: empty subject", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// signingKey ищет ключ по kid, при необходимости перечитывая JWKS (ротация ключей у провайдера)
+func (p *oidcProvider) signingKey(ctx context.Context, kid string) (interface{}, error) {
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	p.mu.RLock()
+	canRefresh := time.Since(p.keysFetchedAt) > jwksRefreshInterval
+	p.mu.RUnlock()
+
+	if canRefresh {
+		if err := p.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+		if key, ok := p.lookupKey(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownSigningKey
+}
+
+func (p *oidcProvider) lookupKey(kid string) (interface{}, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Если провайдер не указывает kid, допускаем единственный ключ
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *oidcProvider) refreshKeys(ctx context.Context) error {
+	var set jsonWebKeySet
+	if err := p.getJSON(ctx, p.jwksURL, "", &set); err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Неподдерживаемые типы ключей пропускаем
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,35 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrEmptyAccessToken = errors.New("oauth provider returned empty access token")
+
+// Token - ответ токен-эндпоинта провайдера
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	IDToken      string `json:"id_token"`
+}
+
+// UserInfo - данные пользователя, приведённые к общему виду для всех провайдеров
+type UserInfo struct {
+	ID            string
+	Email         string
+	EmailVerified bool
+	Name          string
+	AvatarURL     string
+}
+
+// Provider - OAuth2-провайдер с поддержкой authorization code + PKCE.
+// nonce используется только OIDC-провайдерами для привязки ID-токена к запросу.
+type Provider interface {
+	Name() string
+	AuthCodeURL(state, codeChallenge, nonce string) string
+	Exchange(ctx context.Context, code, codeVerifier string) (*Token, error)
+	UserInfo(ctx context.Context, token *Token, nonce string) (*UserInfo, error)
+}
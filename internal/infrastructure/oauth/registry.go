@@ -0,0 +1,53 @@
+package oauth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrDuplicateProvider = errors.New("duplicate oauth provider")
+
+// Registry - набор OAuth/OIDC провайдеров, доступных в приложении.
+// Провайдеры регистрируются из конфигурации при старте сервера.
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Provider)}
+}
+
+// Register добавляет провайдер в реестр. Имена, совпадающие с уже зарегистрированным
+// или дающие тот же префикс переменных OIDC_<NAME>_* (my-idp и my_idp), отклоняются.
+func (r *Registry) Register(provider Provider) error {
+	name := provider.Name()
+	for existing := range r.providers {
+		if envName(existing) == envName(name) {
+			return fmt.Errorf("%w: %s conflicts with %s", ErrDuplicateProvider, name, existing)
+		}
+	}
+	r.providers[name] = provider
+	return nil
+}
+
+func (r *Registry) Get(name string) (Provider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// Names возвращает отсортированный список зарегистрированных провайдеров
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// envName приводит имя провайдера к виду, в котором оно входит в имена переменных окружения
+func envName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
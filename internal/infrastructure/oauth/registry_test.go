@@ -0,0 +1,49 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type namedProvider string
+
+func (p namedProvider) Name() string { return string(p) }
+
+func (p namedProvider) AuthCodeURL(string, string, string) string { return "" }
+
+func (p namedProvider) Exchange(context.Context, string, string) (*Token, error) { return nil, nil }
+
+func (p namedProvider) UserInfo(context.Context, *Token, string) (*UserInfo, error) { return nil, nil }
+
+func TestRegistryRegister(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr bool
+	}{
+		{name: "distinct names", names: []string{"google", "yandex", "keycloak"}},
+		{name: "same name", names: []string{"google", "google"}, wantErr: true},
+		{name: "same env prefix", names: []string{"my-idp", "my_idp"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			var err error
+			for _, name := range tt.names {
+				if err = registry.Register(namedProvider(name)); err != nil {
+					break
+				}
+			}
+			if errors.Is(err, ErrDuplicateProvider) != tt.wantErr {
+				t.Fatalf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if got := registry.Names(); len(got) != len(tt.names)-1 {
+					t.Errorf("Names() = %v, duplicate must not replace the registered provider", got)
+				}
+			}
+		})
+	}
+}
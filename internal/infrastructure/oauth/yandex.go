@@ -0,0 +1,56 @@
+package oauth
+
+import (
+	"backend_go/internal/infrastructure/config"
+	"context"
+	"fmt"
+)
+
+const yandexAvatarURLTemplate = "https://avatars.yandex.net/get-yapic/%s/islands-200"
+
+type yandexProvider struct {
+	client
+}
+
+func NewYandexProvider(cfg config.OAuthProviderConfig) Provider {
+	return &yandexProvider{client: newClient(cfg)}
+}
+
+func (p *yandexProvider) Name() string {
+	return "yandex"
+}
+
+type yandexUserInfo struct {
+	ID              string `json:"id"`
+	DefaultEmail    string `json:"default_email"`
+	RealName        string `json:"real_name"`
+	DisplayName     string `json:"display_name"`
+	DefaultAvatarID string `json:"default_avatar_id"`
+	IsAvatarEmpty   bool   `json:"is_avatar_empty"`
+}
+
+func (p *yandexProvider) UserInfo(ctx context.Context, token *Token, nonce string) (*UserInfo, error) {
+	var info yandexUserInfo
+	// Яндекс ожидает токен в формате "OAuth <token>"
+	if err := p.getJSON(ctx, p.cfg.UserInfoURL, "OAuth "+token.AccessToken, &info); err != nil {
+		return nil, fmt.Errorf("failed to fetch yandex user info: %w", err)
+	}
+
+	name := info.RealName
+	if name == "" {
+		name = info.DisplayName
+	}
+
+	userInfo := &UserInfo{
+		ID:    info.ID,
+		Email: info.DefaultEmail,
+		// Яндекс отдаёт только подтверждённые адреса
+		EmailVerified: info.DefaultEmail != "",
+		Name:          name,
+	}
+	if !info.IsAvatarEmpty && info.DefaultAvatarID != "" {
+		userInfo.AvatarURL = fmt.Sprintf(yandexAvatarURLTemplate, info.DefaultAvatarID)
+	}
+
+	return userInfo, nil
+}
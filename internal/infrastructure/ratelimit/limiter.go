@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit - параметры token bucket: Burst токенов в ведре, пополнение Rate токенов за Period
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// PerMinute - лимит в n запросов в минуту с ведром того же размера
+func PerMinute(n int) Limit {
+	return Limit{Rate: n, Period: time.Minute, Burst: n}
+}
+
+// Enabled возвращает false для нулевого или отрицательного лимита - такой лимит не применяется
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0 && l.Period > 0
+}
+
+// interval - время пополнения одного токена
+func (l Limit) interval() time.Duration {
+	return l.Period / time.Duration(l.Rate)
+}
+
+// Result - результат проверки лимита
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter - через сколько ведро наполнится полностью
+	ResetAfter time.Duration
+	// RetryAfter - через сколько появится следующий токен, если запрос отклонён
+	RetryAfter time.Duration
+}
+
+// Limiter списывает токен из ведра ключа
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// newResult считает поля Result по числу оставшихся (дробных) токенов
+func newResult(allowed bool, tokens float64, limit Limit) Result {
+	interval := limit.interval()
+
+	result := Result{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Remaining:  int(tokens),
+		ResetAfter: time.Duration((float64(limit.Burst) - tokens) * float64(interval)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(interval))
+	}
+
+	return result
+}
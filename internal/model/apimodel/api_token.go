@@ -0,0 +1,29 @@
+package apimodel
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type APITokenCreate struct {
+	Name   string   `json:"name" binding:"required,name"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,api_scope"`
+	// ExpiresInDays - срок жизни токена; без него токен действует до отзыва
+	ExpiresInDays *int `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+type APIToken struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// APITokenCreated возвращается один раз при создании: позже получить токен будет нельзя
+type APITokenCreated struct {
+	APIToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,5 @@
+package apimodel
+
+type EmailVerify struct {
+	Token string `json:"token" binding:"required"`
+}
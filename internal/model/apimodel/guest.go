@@ -0,0 +1,12 @@
+package apimodel
+
+type GuestLogin struct {
+	Name string `json:"name" binding:"required,name"`
+}
+
+// GuestUpgrade - превращение гостевого аккаунта в обычный с сохранением id и истории
+type GuestUpgrade struct {
+	Name     *string `json:"name" binding:"omitempty,name"`
+	Email    string  `json:"email" binding:"required,email,max=254"`
+	Password string  `json:"password" binding:"required,password"`
+}
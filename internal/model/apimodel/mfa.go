@@ -0,0 +1,32 @@
+package apimodel
+
+// MFAEnrollment - данные для подключения приложения-аутентификатора.
+// otpauth_uri удобно показать пользователю в виде QR-кода.
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// MFACode - код из приложения-аутентификатора или код восстановления
+type MFACode struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// MFARecoveryCodes показываются пользователю один раз, в БД хранятся только их хеши
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAVerify - второй шаг входа: токен из ответа Login и код
+type MFAVerify struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required,max=32"`
+}
+
+// LoginResponse - ответ на вход. Если у пользователя включена 2FA, токенов нет,
+// а mfa_token нужно обменять на них через /api/auth/mfa/verify.
+type LoginResponse struct {
+	*TokenResponse
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+}
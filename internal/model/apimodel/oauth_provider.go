@@ -0,0 +1,40 @@
+package apimodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var oauthProviderNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// OAuthProvider - представление провайдера в API (в нижнем регистре)
+type OAuthProvider string
+
+func (p OAuthProvider) IsValid() bool {
+	return oauthProviderNamePattern.MatchString(string(p))
+}
+
+func (p OAuthProvider) MarshalJSON() ([]byte, error) {
+	normalized := OAuthProvider(strings.ToLower(string(p)))
+	if !normalized.IsValid() {
+		return nil, fmt.Errorf("invalid oauth provider name %q", string(p))
+	}
+	return json.Marshal(string(normalized))
+}
+
+func (p *OAuthProvider) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	normalized := OAuthProvider(strings.ToLower(strings.TrimSpace(value)))
+	if !normalized.IsValid() {
+		return fmt.Errorf("invalid oauth provider name %q", value)
+	}
+
+	*p = normalized
+	return nil
+}
@@ -0,0 +1,90 @@
+package apimodel
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOAuthProviderMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider OAuthProvider
+		want     string
+		wantErr  bool
+	}{
+		{name: "google", provider: "google", want: `"google"`},
+		{name: "yandex", provider: "yandex", want: `"yandex"`},
+		{name: "database google", provider: "GOOGLE", want: `"google"`},
+		{name: "database yandex", provider: "YANDEX", want: `"yandex"`},
+		{name: "configured oidc provider", provider: "KEYCLOAK", want: `"keycloak"`},
+		{name: "empty", provider: "", wantErr: true},
+		{name: "invalid name", provider: "goo gle", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.provider)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProviderUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    OAuthProvider
+		wantErr bool
+	}{
+		{name: "google", data: `"google"`, want: "google"},
+		{name: "database google", data: `"GOOGLE"`, want: "google"},
+		{name: "database yandex", data: `"YANDEX"`, want: "yandex"},
+		{name: "surrounding spaces", data: `" yandex "`, want: "yandex"},
+		{name: "configured oidc provider", data: `"my-idp"`, want: "my-idp"},
+		{name: "empty", data: `""`, wantErr: true},
+		{name: "invalid name", data: `"goo gle"`, wantErr: true},
+		{name: "not a string", data: `true`, wantErr: true},
+		{name: "null into value", data: `null`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got OAuthProvider
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserResponseOAuthProviderNull(t *testing.T) {
+	data, err := json.Marshal(UserResponse{})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if value, ok := fields["oauth_provider"]; ok && string(value) != "null" {
+		t.Errorf("oauth_provider = %s, want null or omitted", value)
+	}
+
+	got := UserResponse{OAuthProvider: new(OAuthProvider)}
+	if err := json.Unmarshal([]byte(`{"oauth_provider":null}`), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.OAuthProvider != nil {
+		t.Errorf("OAuthProvider = %q, want nil", *got.OAuthProvider)
+	}
+}
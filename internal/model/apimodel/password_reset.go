@@ -0,0 +1,10 @@
+package apimodel
+
+type PasswordForgot struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type PasswordReset struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password"`
+}
@@ -3,15 +3,43 @@ package apimodel
 import "time"
 
 type Session struct {
-	ID            string     `json:"id"`
-	Name          string     `json:"name"`
-	DeckType      string     `json:"deck_type"`
-	CardsRevealed bool       `json:"cards_revealed"`
-	CreatorID     string     `json:"creator_id"`
-	CreatorName   string     `json:"creator_name"`
-	AllowEmoji    bool       `json:"allow_emoji"`
-	AutoReveal    bool       `json:"auto_reveal"`
-	CreatedVia    string     `json:"created_via"`
-	CreatedAt     *time.Time `json:"created_at,omitempty"`
-	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	DeckType            string     `json:"deck_type"`
+	CardsRevealed       bool       `json:"cards_revealed"`
+	CreatorID           string     `json:"creator_id"`
+	CreatorName         string     `json:"creator_name"`
+	AllowEmoji          bool       `json:"allow_emoji"`
+	AutoReveal          bool       `json:"auto_reveal"`
+	CreatedVia          string     `json:"created_via"`
+	JoinCode            string     `json:"join_code"`
+	HasPasscode         bool       `json:"has_passcode"`
+	AllowedEmailDomains []string   `json:"allowed_email_domains"`
+	TimerSeconds        *int       `json:"timer_seconds,omitempty"`
+	ArchivedAt          *time.Time `json:"archived_at,omitempty"`
+	LastActivityAt      *time.Time `json:"last_activity_at,omitempty"`
+	CreatedAt           *time.Time `json:"created_at,omitempty"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+}
+
+// SessionListQuery - параметры GET /api/sessions
+type SessionListQuery struct {
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor      string     `form:"cursor" binding:"omitempty,max=512"`
+	Search      string     `form:"q" binding:"omitempty,max=128"`
+	DeckType    string     `form:"deck_type" binding:"omitempty,deck_type"`
+	CreatedFrom *time.Time `form:"created_from"`
+	CreatedTo   *time.Time `form:"created_to"`
+	Status      string     `form:"status" binding:"omitempty,oneof=active archived all"`
+	Role        string     `form:"role" binding:"omitempty,oneof=created participated all"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=created_at name last_activity"`
+	Order       string     `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// SessionPage - страница списка сессий. NextCursor пустой на последней странице,
+// TotalCount - число сессий по фильтрам без учёта курсора.
+type SessionPage struct {
+	Items      []*Session `json:"items"`
+	NextCursor *string    `json:"next_cursor"`
+	TotalCount int        `json:"total_count"`
 }
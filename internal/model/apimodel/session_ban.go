@@ -0,0 +1,17 @@
+package apimodel
+
+import "time"
+
+type SessionBan struct {
+	SessionID string     `json:"session_id"`
+	UserID    string     `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	BannedBy  *string    `json:"banned_by,omitempty"`
+	Reason    *string    `json:"reason,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type SessionBanCreate struct {
+	UserID string  `json:"user_id" binding:"required,uuid"`
+	Reason *string `json:"reason" binding:"omitempty,max=256"`
+}
@@ -0,0 +1,24 @@
+package apimodel
+
+// SessionCreate - новая сессия. С TemplateID незаполненные поля берутся из шаблона.
+type SessionCreate struct {
+	Name         string   `json:"name" binding:"required,max=128"`
+	TemplateID   *string  `json:"template_id" binding:"omitempty,uuid"`
+	DeckType     string   `json:"deck_type" binding:"required_without=TemplateID,omitempty,deck_type"`
+	AllowEmoji   *bool    `json:"allow_emoji"`
+	AutoReveal   *bool    `json:"auto_reveal"`
+	TimerSeconds *int     `json:"timer_seconds" binding:"omitempty,min=10,max=3600"`
+	Stories      []string `json:"stories" binding:"omitempty,max=100,dive,story_title"`
+	// Passcode - код-пароль, без которого нельзя войти в сессию
+	Passcode *string `json:"passcode" binding:"omitempty,min=4,max=64"`
+	// AllowedEmailDomains ограничивает вход пользователями с подтверждённым email из этих доменов
+	AllowedEmailDomains []string `json:"allowed_email_domains" binding:"omitempty,max=20,dive,fqdn"`
+}
+
+// SessionAccessUpdate меняет ограничения входа; не переданные поля не меняются
+type SessionAccessUpdate struct {
+	Passcode       *string `json:"passcode" binding:"omitempty,min=4,max=64"`
+	RemovePasscode bool    `json:"remove_passcode"`
+	// AllowedEmailDomains заменяет список целиком, пустой список снимает ограничение
+	AllowedEmailDomains *[]string `json:"allowed_email_domains" binding:"omitempty,max=20,dive,fqdn"`
+}
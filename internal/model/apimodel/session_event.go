@@ -0,0 +1,17 @@
+package apimodel
+
+import "time"
+
+type SessionEvent struct {
+	ID           string     `json:"id"`
+	SessionID    string     `json:"session_id"`
+	ActorID      *string    `json:"actor_id,omitempty"`
+	Type         string     `json:"type"`
+	TargetUserID *string    `json:"target_user_id,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+}
+
+// SessionParticipantRef - участник, над которым выполняется действие (передача прав, соведущий)
+type SessionParticipantRef struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
@@ -0,0 +1,50 @@
+package apimodel
+
+import "time"
+
+type SessionInviteCreate struct {
+	// ExpiresInHours - срок действия ссылки; без него ссылка действует до отзыва
+	ExpiresInHours *int `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+	// MaxUses - сколько раз по ссылке можно войти; без него без ограничений
+	MaxUses *int `json:"max_uses" binding:"omitempty,min=1,max=1000"`
+}
+
+type SessionInvite struct {
+	ID        string     `json:"id"`
+	SessionID string     `json:"session_id"`
+	Token     string     `json:"token"`
+	URL       string     `json:"url"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	Uses      int        `json:"uses"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// SessionInvitePreview - что видит получатель ссылки до входа в сессию
+type SessionInvitePreview struct {
+	SessionID   string     `json:"session_id"`
+	SessionName string     `json:"session_name"`
+	CreatorName string     `json:"creator_name"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// SessionInviteAccept - вход по ссылке. Без авторизации по имени создаётся гостевой аккаунт.
+type SessionInviteAccept struct {
+	Name       *string `json:"name" binding:"omitempty,name"`
+	AsObserver bool    `json:"as_observer"`
+}
+
+// SessionInviteAccepted - результат входа по ссылке; Tokens заполнен, только если был создан гость
+type SessionInviteAccepted struct {
+	Tokens      *TokenResponse      `json:"tokens,omitempty"`
+	Session     *Session            `json:"session"`
+	Participant *SessionParticipant `json:"participant"`
+}
+
+// SessionJoinByCode - вход в сессию по короткому коду
+type SessionJoinByCode struct {
+	Code       string  `json:"code" binding:"required,max=16"`
+	AsObserver bool    `json:"as_observer"`
+	Passcode   *string `json:"passcode" binding:"omitempty,max=64"`
+}
@@ -0,0 +1,25 @@
+package apimodel
+
+import "time"
+
+type SessionParticipant struct {
+	SessionID string     `json:"session_id"`
+	UserID    string     `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Role      string     `json:"role"`
+	JoinedAt  *time.Time `json:"joined_at,omitempty"`
+}
+
+// SessionJoin - вход в сессию. Наблюдатель видит голосование, но не голосует.
+type SessionJoin struct {
+	AsObserver bool    `json:"as_observer"`
+	Passcode   *string `json:"passcode" binding:"omitempty,max=64"`
+}
+
+type SessionRoleUpdate struct {
+	Role string `json:"role" binding:"required,session_role"`
+}
+
+type UserRoleUpdate struct {
+	Role string `json:"role" binding:"required,user_role"`
+}
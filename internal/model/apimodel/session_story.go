@@ -0,0 +1,28 @@
+package apimodel
+
+import "time"
+
+type SessionStory struct {
+	ID        string     `json:"id"`
+	SessionID string     `json:"session_id"`
+	Title     string     `json:"title"`
+	Position  int        `json:"position"`
+	Estimate  *string    `json:"estimate,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type SessionStoryCreate struct {
+	Title string `json:"title" binding:"required,story_title"`
+}
+
+type SessionStoryEstimate struct {
+	Estimate string `json:"estimate" binding:"required,vote_value"`
+}
+
+// SessionClone - новая сессия с настройками существующей
+type SessionClone struct {
+	// Name - название новой сессии; по умолчанию берётся из исходной
+	Name *string `json:"name" binding:"omitempty,max=128"`
+	// CarryOverStories переносит в новую сессию задачи, которые не успели оценить
+	CarryOverStories bool `json:"carry_over_stories"`
+}
@@ -0,0 +1,25 @@
+package apimodel
+
+import "time"
+
+type SessionTemplate struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	DeckType     string     `json:"deck_type"`
+	AllowEmoji   bool       `json:"allow_emoji"`
+	AutoReveal   bool       `json:"auto_reveal"`
+	TimerSeconds *int       `json:"timer_seconds,omitempty"`
+	Stories      []string   `json:"stories"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// SessionTemplateSave - создание и замена шаблона целиком
+type SessionTemplateSave struct {
+	Name         string   `json:"name" binding:"required,max=128"`
+	DeckType     string   `json:"deck_type" binding:"required,deck_type"`
+	AllowEmoji   *bool    `json:"allow_emoji"`
+	AutoReveal   *bool    `json:"auto_reveal"`
+	TimerSeconds *int     `json:"timer_seconds" binding:"omitempty,min=10,max=3600"`
+	Stories      []string `json:"stories" binding:"omitempty,max=100,dive,story_title"`
+}
@@ -0,0 +1,14 @@
+package apimodel
+
+import "time"
+
+// UserDataExport - выгрузка всех данных пользователя (GDPR)
+type UserDataExport struct {
+	ExportedAt        time.Time   `json:"exported_at"`
+	Profile           UserProfile `json:"profile"`
+	CreatedAt         *time.Time  `json:"created_at,omitempty"`
+	SessionsCreated   []*Session  `json:"sessions_created"`
+	Votes             []*Vote     `json:"votes"`
+	ReactionsSent     []*Reaction `json:"reactions_sent"`
+	ReactionsReceived []*Reaction `json:"reactions_received"`
+}
@@ -1,6 +1,6 @@
 package apimodel
 
 type UserLogin struct {
-	Email    string
-	Password string
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
 }
@@ -12,6 +12,7 @@ type UserProfile struct {
 	IsActive      bool                       `json:"is_active"`
 	IsVerified    bool                       `json:"is_verified"`
 	IsGuest       bool                       `json:"is_guest"`
+	Role          string                     `json:"role"`
 	OAuthProvider *entitymodel.OAuthProvider `json:"oauth_provider,omitempty"`
 	AvatarUrl     string                     `json:"avatar_url"`
 }
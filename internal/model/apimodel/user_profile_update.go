@@ -0,0 +1,13 @@
+package apimodel
+
+// UserProfileUpdate - частичное обновление профиля: отсутствующие поля не меняются,
+// пустой avatar_url удаляет аватар
+type UserProfileUpdate struct {
+	Name      *string `json:"name" binding:"omitempty,name"`
+	AvatarURL *string `json:"avatar_url" binding:"omitempty,max=2048"`
+}
+
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,password"`
+}
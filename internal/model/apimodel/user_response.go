@@ -4,13 +4,6 @@ import (
 	"time"
 )
 
-type OAuthProvider string
-
-const (
-	Google OAuthProvider = "google"
-	Yandex OAuthProvider = "yandex"
-)
-
 type UserResponse struct {
 	ID             string         `json:"id"`
 	Name           string         `json:"name"`
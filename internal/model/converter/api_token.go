@@ -0,0 +1,64 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func APITokenDBToEntity(token *dbmodel.APIToken) *entitymodel.APIToken {
+	if token == nil {
+		return nil
+	}
+
+	createdAt := token.CreatedAt
+	return &entitymodel.APIToken{
+		ID:          token.ID,
+		UserID:      token.UserID,
+		Name:        token.Name,
+		TokenHash:   token.TokenHash,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      []string(token.Scopes),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   &createdAt,
+	}
+}
+
+func APITokenEntityToDB(token *entitymodel.APIToken) *dbmodel.APIToken {
+	if token == nil {
+		return nil
+	}
+
+	dbToken := &dbmodel.APIToken{
+		ID:          token.ID,
+		UserID:      token.UserID,
+		Name:        token.Name,
+		TokenHash:   token.TokenHash,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+	}
+	if token.CreatedAt != nil {
+		dbToken.CreatedAt = *token.CreatedAt
+	}
+
+	return dbToken
+}
+
+func APITokenEntityToAPI(token *entitymodel.APIToken) *apimodel.APIToken {
+	if token == nil {
+		return nil
+	}
+
+	return &apimodel.APIToken{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
@@ -0,0 +1,25 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"strings"
+)
+
+// OAuthProviderEntityToDB переводит доменное значение в значение колонки users.oauth_provider (в верхнем регистре)
+func OAuthProviderEntityToDB(provider entitymodel.OAuthProvider) dbmodel.OAuthProvider {
+	return dbmodel.OAuthProvider(strings.ToUpper(string(provider)))
+}
+
+func OAuthProviderDBToEntity(provider dbmodel.OAuthProvider) entitymodel.OAuthProvider {
+	return entitymodel.OAuthProvider(strings.ToLower(string(provider)))
+}
+
+func OAuthProviderEntityToAPI(provider entitymodel.OAuthProvider) apimodel.OAuthProvider {
+	return apimodel.OAuthProvider(strings.ToLower(string(provider)))
+}
+
+func OAuthProviderAPIToEntity(provider apimodel.OAuthProvider) entitymodel.OAuthProvider {
+	return entitymodel.OAuthProvider(strings.ToLower(string(provider)))
+}
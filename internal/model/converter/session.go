@@ -12,17 +12,23 @@ func SessionEntityToAPI(session *entitymodel.Session) *apimodel.Session {
 	}
 
 	return &apimodel.Session{
-		ID:            session.ID,
-		Name:          session.Name,
-		DeckType:      session.DeckType,
-		CardsRevealed: session.CardsRevealed,
-		CreatorID:     session.CreatorID,
-		CreatorName:   session.CreatorName,
-		AllowEmoji:    session.AllowEmoji,
-		AutoReveal:    session.AutoReveal,
-		CreatedVia:    session.CreatedVia,
-		CreatedAt:     session.CreatedAt,
-		UpdatedAt:     session.UpdatedAt,
+		ID:                  session.ID,
+		Name:                session.Name,
+		DeckType:            session.DeckType,
+		CardsRevealed:       session.CardsRevealed,
+		CreatorID:           session.CreatorID,
+		CreatorName:         session.CreatorName,
+		AllowEmoji:          session.AllowEmoji,
+		AutoReveal:          session.AutoReveal,
+		CreatedVia:          session.CreatedVia,
+		JoinCode:            session.JoinCode,
+		HasPasscode:         session.HasPasscode(),
+		AllowedEmailDomains: session.AllowedEmailDomains,
+		TimerSeconds:        session.TimerSeconds,
+		ArchivedAt:          session.ArchivedAt,
+		LastActivityAt:      session.LastActivityAt,
+		CreatedAt:           session.CreatedAt,
+		UpdatedAt:           session.UpdatedAt,
 	}
 }
 
@@ -32,16 +38,22 @@ func SessionDBToEntity(session *dbmodel.Session) *entitymodel.Session {
 	}
 
 	entitySession := &entitymodel.Session{
-		ID:            session.ID,
-		Name:          session.Name,
-		DeckType:      session.DeckType,
-		CardsRevealed: session.CardsRevealed,
-		CreatorID:     session.CreatorID,
-		CreatorName:   session.CreatorName,
-		AllowEmoji:    session.AllowEmoji,
-		AutoReveal:    session.AutoReveal,
-		CreatedVia:    session.CreatedVia,
-		CreatedAt:     &session.CreatedAt,
+		ID:                  session.ID,
+		Name:                session.Name,
+		DeckType:            session.DeckType,
+		CardsRevealed:       session.CardsRevealed,
+		CreatorID:           session.CreatorID,
+		CreatorName:         session.CreatorName,
+		AllowEmoji:          session.AllowEmoji,
+		AutoReveal:          session.AutoReveal,
+		CreatedVia:          session.CreatedVia,
+		JoinCode:            session.JoinCode,
+		PasscodeHash:        session.PasscodeHash,
+		AllowedEmailDomains: session.AllowedEmailDomains,
+		TimerSeconds:        session.TimerSeconds,
+		ArchivedAt:          session.ArchivedAt,
+		LastActivityAt:      session.LastActivityAt,
+		CreatedAt:           &session.CreatedAt,
 	}
 
 	// Конвертируем UpdatedAt
@@ -59,17 +71,21 @@ func SessionAPIToEntity(session *apimodel.Session) *entitymodel.Session {
 	}
 
 	return &entitymodel.Session{
-		ID:            session.ID,
-		Name:          session.Name,
-		DeckType:      session.DeckType,
-		CardsRevealed: session.CardsRevealed,
-		CreatorID:     session.CreatorID,
-		CreatorName:   session.CreatorName,
-		AllowEmoji:    session.AllowEmoji,
-		AutoReveal:    session.AutoReveal,
-		CreatedVia:    session.CreatedVia,
-		CreatedAt:     session.CreatedAt,
-		UpdatedAt:     session.UpdatedAt,
+		ID:                  session.ID,
+		Name:                session.Name,
+		DeckType:            session.DeckType,
+		CardsRevealed:       session.CardsRevealed,
+		CreatorID:           session.CreatorID,
+		CreatorName:         session.CreatorName,
+		AllowEmoji:          session.AllowEmoji,
+		AutoReveal:          session.AutoReveal,
+		CreatedVia:          session.CreatedVia,
+		JoinCode:            session.JoinCode,
+		AllowedEmailDomains: session.AllowedEmailDomains,
+		TimerSeconds:        session.TimerSeconds,
+		ArchivedAt:          session.ArchivedAt,
+		CreatedAt:           session.CreatedAt,
+		UpdatedAt:           session.UpdatedAt,
 	}
 }
 
@@ -79,15 +95,20 @@ func SessionEntityToDB(session *entitymodel.Session) *dbmodel.Session {
 	}
 
 	dbSession := &dbmodel.Session{
-		ID:            session.ID,
-		Name:          session.Name,
-		DeckType:      session.DeckType,
-		CardsRevealed: session.CardsRevealed,
-		CreatorID:     session.CreatorID,
-		CreatorName:   session.CreatorName,
-		AllowEmoji:    session.AllowEmoji,
-		AutoReveal:    session.AutoReveal,
-		CreatedVia:    session.CreatedVia,
+		ID:                  session.ID,
+		Name:                session.Name,
+		DeckType:            session.DeckType,
+		CardsRevealed:       session.CardsRevealed,
+		CreatorID:           session.CreatorID,
+		CreatorName:         session.CreatorName,
+		AllowEmoji:          session.AllowEmoji,
+		AutoReveal:          session.AutoReveal,
+		CreatedVia:          session.CreatedVia,
+		JoinCode:            session.JoinCode,
+		PasscodeHash:        session.PasscodeHash,
+		AllowedEmailDomains: session.AllowedEmailDomains,
+		TimerSeconds:        session.TimerSeconds,
+		ArchivedAt:          session.ArchivedAt,
 	}
 
 	// Конвертируем время
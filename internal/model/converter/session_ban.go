@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func SessionBanDBToEntity(ban *dbmodel.SessionBan) *entitymodel.SessionBan {
+	if ban == nil {
+		return nil
+	}
+
+	createdAt := ban.CreatedAt
+	return &entitymodel.SessionBan{
+		SessionID: ban.SessionID,
+		UserID:    ban.UserID,
+		UserName:  ban.UserName,
+		BannedBy:  ban.BannedBy,
+		Reason:    ban.Reason,
+		CreatedAt: &createdAt,
+	}
+}
+
+func SessionBanEntityToAPI(ban *entitymodel.SessionBan) *apimodel.SessionBan {
+	if ban == nil {
+		return nil
+	}
+
+	return &apimodel.SessionBan{
+		SessionID: ban.SessionID,
+		UserID:    ban.UserID,
+		UserName:  ban.UserName,
+		BannedBy:  ban.BannedBy,
+		Reason:    ban.Reason,
+		CreatedAt: ban.CreatedAt,
+	}
+}
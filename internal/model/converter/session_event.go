@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func SessionEventDBToEntity(event *dbmodel.SessionEvent) *entitymodel.SessionEvent {
+	if event == nil {
+		return nil
+	}
+
+	createdAt := event.CreatedAt
+	return &entitymodel.SessionEvent{
+		ID:           event.ID,
+		SessionID:    event.SessionID,
+		ActorID:      event.ActorID,
+		Type:         event.Type,
+		TargetUserID: event.TargetUserID,
+		CreatedAt:    &createdAt,
+	}
+}
+
+func SessionEventEntityToAPI(event *entitymodel.SessionEvent) *apimodel.SessionEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &apimodel.SessionEvent{
+		ID:           event.ID,
+		SessionID:    event.SessionID,
+		ActorID:      event.ActorID,
+		Type:         event.Type,
+		TargetUserID: event.TargetUserID,
+		CreatedAt:    event.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func SessionInviteDBToEntity(invite *dbmodel.SessionInvite) *entitymodel.SessionInvite {
+	if invite == nil {
+		return nil
+	}
+
+	createdAt := invite.CreatedAt
+	return &entitymodel.SessionInvite{
+		ID:        invite.ID,
+		SessionID: invite.SessionID,
+		CreatedBy: invite.CreatedBy,
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		CreatedAt: &createdAt,
+	}
+}
+
+// SessionInviteEntityToAPI: token и url подписываются в сервисе и в БД не хранятся
+func SessionInviteEntityToAPI(invite *entitymodel.SessionInvite, token, url string) *apimodel.SessionInvite {
+	if invite == nil {
+		return nil
+	}
+
+	return &apimodel.SessionInvite{
+		ID:        invite.ID,
+		SessionID: invite.SessionID,
+		Token:     token,
+		URL:       url,
+		CreatedBy: invite.CreatedBy,
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		CreatedAt: invite.CreatedAt,
+	}
+}
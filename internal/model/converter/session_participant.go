@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func SessionParticipantDBToEntity(participant *dbmodel.SessionParticipant) *entitymodel.SessionParticipant {
+	if participant == nil {
+		return nil
+	}
+
+	joinedAt := participant.JoinedAt
+	return &entitymodel.SessionParticipant{
+		SessionID: participant.SessionID,
+		UserID:    participant.UserID,
+		UserName:  participant.UserName,
+		Role:      participant.Role,
+		JoinedAt:  &joinedAt,
+	}
+}
+
+func SessionParticipantEntityToAPI(participant *entitymodel.SessionParticipant) *apimodel.SessionParticipant {
+	if participant == nil {
+		return nil
+	}
+
+	return &apimodel.SessionParticipant{
+		SessionID: participant.SessionID,
+		UserID:    participant.UserID,
+		UserName:  participant.UserName,
+		Role:      participant.Role,
+		JoinedAt:  participant.JoinedAt,
+	}
+}
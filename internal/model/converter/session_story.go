@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func SessionStoryDBToEntity(story *dbmodel.SessionStory) *entitymodel.SessionStory {
+	if story == nil {
+		return nil
+	}
+
+	createdAt := story.CreatedAt
+	return &entitymodel.SessionStory{
+		ID:        story.ID,
+		SessionID: story.SessionID,
+		Title:     story.Title,
+		Position:  story.Position,
+		Estimate:  story.Estimate,
+		CreatedAt: &createdAt,
+	}
+}
+
+func SessionStoryEntityToAPI(story *entitymodel.SessionStory) *apimodel.SessionStory {
+	if story == nil {
+		return nil
+	}
+
+	return &apimodel.SessionStory{
+		ID:        story.ID,
+		SessionID: story.SessionID,
+		Title:     story.Title,
+		Position:  story.Position,
+		Estimate:  story.Estimate,
+		CreatedAt: story.CreatedAt,
+	}
+}
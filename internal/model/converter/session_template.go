@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+)
+
+func SessionTemplateDBToEntity(template *dbmodel.SessionTemplate) *entitymodel.SessionTemplate {
+	if template == nil {
+		return nil
+	}
+
+	createdAt := template.CreatedAt
+	return &entitymodel.SessionTemplate{
+		ID:           template.ID,
+		OwnerID:      template.OwnerID,
+		Name:         template.Name,
+		DeckType:     template.DeckType,
+		AllowEmoji:   template.AllowEmoji,
+		AutoReveal:   template.AutoReveal,
+		TimerSeconds: template.TimerSeconds,
+		Stories:      template.Stories,
+		CreatedAt:    &createdAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
+
+func SessionTemplateEntityToDB(template *entitymodel.SessionTemplate) *dbmodel.SessionTemplate {
+	if template == nil {
+		return nil
+	}
+
+	return &dbmodel.SessionTemplate{
+		ID:           template.ID,
+		OwnerID:      template.OwnerID,
+		Name:         template.Name,
+		DeckType:     template.DeckType,
+		AllowEmoji:   template.AllowEmoji,
+		AutoReveal:   template.AutoReveal,
+		TimerSeconds: template.TimerSeconds,
+		Stories:      template.Stories,
+	}
+}
+
+func SessionTemplateEntityToAPI(template *entitymodel.SessionTemplate) *apimodel.SessionTemplate {
+	if template == nil {
+		return nil
+	}
+
+	return &apimodel.SessionTemplate{
+		ID:           template.ID,
+		Name:         template.Name,
+		DeckType:     template.DeckType,
+		AllowEmoji:   template.AllowEmoji,
+		AutoReveal:   template.AutoReveal,
+		TimerSeconds: template.TimerSeconds,
+		Stories:      template.Stories,
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
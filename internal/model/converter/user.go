@@ -34,7 +34,7 @@ func UserEntityToAPI(user *entitymodel.User) *apimodel.UserResponse {
 
 	// Копируем OAuth поля, если они не nil
 	if user.OAuthProvider != nil {
-		oauthProvider := apimodel.OAuthProvider(*user.OAuthProvider)
+		oauthProvider := OAuthProviderEntityToAPI(*user.OAuthProvider)
 		apiUser.OAuthProvider = &oauthProvider
 	}
 	if user.OAuthID != nil {
@@ -68,6 +68,7 @@ func UserDBToEntity(user *dbmodel.User) *entitymodel.User {
 		IsActive:       user.IsActive,
 		IsVerified:     user.IsVerified,
 		IsGuest:        user.IsGuest,
+		Role:           user.Role,
 		OAuthID:        user.OAuthID,
 		AvatarURL:      user.AvatarURL,
 		IsCreator:      user.IsCreator,
@@ -75,11 +76,14 @@ func UserDBToEntity(user *dbmodel.User) *entitymodel.User {
 		OnSession:      user.OnSession,
 		SocketID:       user.SocketID,
 		CreatedAt:      &user.CreatedAt,
+		MFASecret:      user.MFASecret,
+		MFAEnabled:     user.MFAEnabled,
+		MFALastStep:    user.MFALastStep,
 	}
 
 	// Конвертируем OAuthProvider
 	if user.OAuthProvider != nil {
-		provider := entitymodel.OAuthProvider(*user.OAuthProvider)
+		provider := OAuthProviderDBToEntity(*user.OAuthProvider)
 		entityUser.OAuthProvider = &provider
 	}
 
@@ -88,6 +92,14 @@ func UserDBToEntity(user *dbmodel.User) *entitymodel.User {
 		updatedAt := *user.UpdatedAt
 		entityUser.UpdatedAt = &updatedAt
 	}
+	if user.TokensValidAfter != nil {
+		tokensValidAfter := *user.TokensValidAfter
+		entityUser.TokensValidAfter = &tokensValidAfter
+	}
+	if user.DeletedAt != nil {
+		deletedAt := *user.DeletedAt
+		entityUser.DeletedAt = &deletedAt
+	}
 
 	return entityUser
 }
@@ -125,7 +137,7 @@ func UserAPIToEntity(user *apimodel.UserResponse) *entitymodel.User {
 
 	// Копируем OAuth поля, если они не nil
 	if user.OAuthProvider != nil {
-		provider := entitymodel.OAuthProvider(*user.OAuthProvider)
+		provider := OAuthProviderAPIToEntity(*user.OAuthProvider)
 		entityUser.OAuthProvider = &provider
 	}
 	if user.OAuthID != nil {
@@ -153,17 +165,21 @@ func UserEntityToDB(user *entitymodel.User) *dbmodel.User {
 		IsActive:       user.IsActive,
 		IsVerified:     user.IsVerified,
 		IsGuest:        user.IsGuest,
+		Role:           user.Role,
 		OAuthID:        user.OAuthID,
 		AvatarURL:      user.AvatarURL,
 		IsCreator:      user.IsCreator,
 		IsWatcher:      user.IsWatcher,
 		OnSession:      user.OnSession,
 		SocketID:       user.SocketID,
+		MFASecret:      user.MFASecret,
+		MFAEnabled:     user.MFAEnabled,
+		MFALastStep:    user.MFALastStep,
 	}
 
 	// Конвертируем OAuthProvider
 	if user.OAuthProvider != nil {
-		provider := dbmodel.OAuthProviderEnum(*user.OAuthProvider)
+		provider := OAuthProviderEntityToDB(*user.OAuthProvider)
 		dbUser.OAuthProvider = &provider
 	}
 
@@ -175,6 +191,14 @@ func UserEntityToDB(user *entitymodel.User) *dbmodel.User {
 		updatedAt := *user.UpdatedAt
 		dbUser.UpdatedAt = &updatedAt
 	}
+	if user.TokensValidAfter != nil {
+		tokensValidAfter := *user.TokensValidAfter
+		dbUser.TokensValidAfter = &tokensValidAfter
+	}
+	if user.DeletedAt != nil {
+		deletedAt := *user.DeletedAt
+		dbUser.DeletedAt = &deletedAt
+	}
 
 	return dbUser
 }
@@ -25,6 +25,7 @@ func ToUserProfile(u *entitymodel.User) apimodel.UserProfile {
 		IsActive:      u.IsActive,
 		IsVerified:    u.IsVerified,
 		IsGuest:       u.IsGuest,
+		Role:          u.Role,
 		OAuthProvider: oauthProvider,
 		AvatarUrl:     avatarURL,
 	}
@@ -0,0 +1,139 @@
+package converter
+
+import (
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUserEntityDBRoundTrip(t *testing.T) {
+	google := entitymodel.OAuthProvider("google")
+	yandex := entitymodel.OAuthProvider("yandex")
+	keycloak := entitymodel.OAuthProvider("keycloak")
+	oauthID := "1234567890"
+	avatarURL := "https://example.com/avatar.png"
+	createdAt := time.Date(2025, 11, 14, 19, 51, 11, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		user   *entitymodel.User
+		wantDB *dbmodel.OAuthProvider
+	}{
+		{
+			name: "google user",
+			user: &entitymodel.User{
+				ID:            uuid.New(),
+				Name:          "Google User",
+				Email:         "google@example.com",
+				IsActive:      true,
+				IsVerified:    true,
+				Role:          entitymodel.RoleUser,
+				OAuthProvider: &google,
+				OAuthID:       &oauthID,
+				AvatarURL:     &avatarURL,
+				CreatedAt:     &createdAt,
+				UpdatedAt:     &updatedAt,
+			},
+			wantDB: providerPtr("GOOGLE"),
+		},
+		{
+			name: "yandex user",
+			user: &entitymodel.User{
+				ID:            uuid.New(),
+				Name:          "Yandex User",
+				Email:         "yandex@example.com",
+				IsActive:      true,
+				Role:          entitymodel.RoleUser,
+				OAuthProvider: &yandex,
+				OAuthID:       &oauthID,
+				CreatedAt:     &createdAt,
+			},
+			wantDB: providerPtr("YANDEX"),
+		},
+		{
+			name: "configured oidc user",
+			user: &entitymodel.User{
+				ID:            uuid.New(),
+				Name:          "Keycloak User",
+				Email:         "sso@example.com",
+				IsActive:      true,
+				IsVerified:    true,
+				Role:          entitymodel.RoleUser,
+				OAuthProvider: &keycloak,
+				OAuthID:       &oauthID,
+				CreatedAt:     &createdAt,
+			},
+			wantDB: providerPtr("KEYCLOAK"),
+		},
+		{
+			name: "password user without provider",
+			user: &entitymodel.User{
+				ID:             uuid.New(),
+				Name:           "Password User",
+				Email:          "user@example.com",
+				HashedPassword: "hash",
+				IsActive:       true,
+				Role:           entitymodel.RoleUser,
+				CreatedAt:      &createdAt,
+			},
+			wantDB: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbUser := UserEntityToDB(tt.user)
+			if !reflect.DeepEqual(dbUser.OAuthProvider, tt.wantDB) {
+				t.Fatalf("UserEntityToDB() provider = %v, want %v", deref(dbUser.OAuthProvider), deref(tt.wantDB))
+			}
+
+			got := UserDBToEntity(dbUser)
+			if !reflect.DeepEqual(got, tt.user) {
+				t.Errorf("UserDBToEntity(UserEntityToDB()) = %+v, want %+v", got, tt.user)
+			}
+		})
+	}
+}
+
+func TestUserDBToEntityProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *dbmodel.OAuthProvider
+		want     *entitymodel.OAuthProvider
+	}{
+		{name: "google", provider: providerPtr("GOOGLE"), want: entityProviderPtr("google")},
+		{name: "yandex", provider: providerPtr("YANDEX"), want: entityProviderPtr("yandex")},
+		{name: "configured oidc provider", provider: providerPtr("MY-IDP"), want: entityProviderPtr("my-idp")},
+		{name: "null", provider: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UserDBToEntity(&dbmodel.User{ID: uuid.NewString(), OAuthProvider: tt.provider})
+			if !reflect.DeepEqual(got.OAuthProvider, tt.want) {
+				t.Errorf("UserDBToEntity() provider = %v, want %v", got.OAuthProvider, tt.want)
+			}
+		})
+	}
+}
+
+func providerPtr(value string) *dbmodel.OAuthProvider {
+	provider := dbmodel.OAuthProvider(value)
+	return &provider
+}
+
+func entityProviderPtr(value string) *entitymodel.OAuthProvider {
+	provider := entitymodel.OAuthProvider(value)
+	return &provider
+}
+
+func deref(provider *dbmodel.OAuthProvider) string {
+	if provider == nil {
+		return "<nil>"
+	}
+	return string(*provider)
+}
@@ -0,0 +1,48 @@
+package dbmodel
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// oauthProviderNamePattern совпадает с CHECK-ограничением ck_users_oauth_provider
+var oauthProviderNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_-]{0,31}$`)
+
+// OAuthProvider - имя провайдера в колонке users.oauth_provider (в верхнем регистре: GOOGLE, YANDEX, KEYCLOAK)
+type OAuthProvider string
+
+func (p OAuthProvider) IsValid() bool {
+	return oauthProviderNamePattern.MatchString(string(p))
+}
+
+// Value реализует driver.Valuer
+func (p OAuthProvider) Value() (driver.Value, error) {
+	normalized := OAuthProvider(strings.ToUpper(string(p)))
+	if !normalized.IsValid() {
+		return nil, fmt.Errorf("invalid oauth_provider value %q", string(p))
+	}
+	return string(normalized), nil
+}
+
+// Scan реализует sql.Scanner
+func (p *OAuthProvider) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into OAuthProvider", src)
+	}
+
+	normalized := OAuthProvider(strings.ToUpper(value))
+	if !normalized.IsValid() {
+		return fmt.Errorf("invalid oauth_provider value %q", value)
+	}
+
+	*p = normalized
+	return nil
+}
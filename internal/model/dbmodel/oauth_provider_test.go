@@ -0,0 +1,104 @@
+package dbmodel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestOAuthProviderValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider OAuthProvider
+		want     driver.Value
+		wantErr  bool
+	}{
+		{name: "google lowercase", provider: "google", want: "GOOGLE"},
+		{name: "yandex lowercase", provider: "yandex", want: "YANDEX"},
+		{name: "already uppercase", provider: "GOOGLE", want: "GOOGLE"},
+		{name: "configured oidc provider", provider: "keycloak", want: "KEYCLOAK"},
+		{name: "empty", provider: "", wantErr: true},
+		{name: "starts with digit", provider: "1google", wantErr: true},
+		{name: "contains space", provider: "goo gle", wantErr: true},
+		{name: "too long", provider: "a234567890123456789012345678901234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.Value()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Value() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProviderValueNull(t *testing.T) {
+	// Пустой указатель записывается в колонку как NULL
+	var provider *OAuthProvider
+	got, err := driver.DefaultParameterConverter.ConvertValue(provider)
+	if err != nil {
+		t.Fatalf("ConvertValue() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("ConvertValue() = %v, want nil", got)
+	}
+}
+
+func TestOAuthProviderScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    OAuthProvider
+		wantErr bool
+	}{
+		{name: "google string", src: "GOOGLE", want: "GOOGLE"},
+		{name: "yandex bytes", src: []byte("YANDEX"), want: "YANDEX"},
+		{name: "lowercase normalized", src: "google", want: "GOOGLE"},
+		{name: "configured oidc provider", src: "KEYCLOAK", want: "KEYCLOAK"},
+		{name: "empty", src: "", wantErr: true},
+		{name: "invalid name", src: "GOO GLE", wantErr: true},
+		{name: "unsupported type", src: int64(1), wantErr: true},
+		{name: "null into value", src: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got OAuthProvider
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Scan() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProviderScanNull(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       interface{}
+		wantValid bool
+		want      OAuthProvider
+	}{
+		{name: "null", src: nil, wantValid: false},
+		{name: "google", src: "GOOGLE", wantValid: true, want: "GOOGLE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sql.Null[OAuthProvider]
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if got.Valid != tt.wantValid || got.V != tt.want {
+				t.Errorf("Scan() = %+v, want valid=%v value=%q", got, tt.wantValid, tt.want)
+			}
+		})
+	}
+}
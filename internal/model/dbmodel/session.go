@@ -1,17 +1,26 @@
 package dbmodel
 
-import "time"
+import (
+	"github.com/lib/pq"
+	"time"
+)
 
 type Session struct {
-	ID            string     `db:"id"`
-	Name          string     `db:"name"`
-	DeckType      string     `db:"deck_type"`
-	CardsRevealed bool       `db:"cards_revealed"`
-	CreatorID     string     `db:"creator_id"`
-	CreatorName   string     `db:"creator_name"`
-	AllowEmoji    bool       `db:"allow_emoji"`
-	AutoReveal    bool       `db:"auto_reveal"`
-	CreatedVia    string     `db:"created_via"`
-	CreatedAt     time.Time  `db:"created_at"`
-	UpdatedAt     *time.Time `db:"updated_at"`
+	ID                  string         `db:"id"`
+	Name                string         `db:"name"`
+	DeckType            string         `db:"deck_type"`
+	CardsRevealed       bool           `db:"cards_revealed"`
+	CreatorID           string         `db:"creator_id"`
+	CreatorName         string         `db:"creator_name"`
+	AllowEmoji          bool           `db:"allow_emoji"`
+	AutoReveal          bool           `db:"auto_reveal"`
+	CreatedVia          string         `db:"created_via"`
+	JoinCode            string         `db:"join_code"`
+	PasscodeHash        *string        `db:"passcode_hash"`
+	AllowedEmailDomains pq.StringArray `db:"allowed_email_domains"`
+	TimerSeconds        *int           `db:"timer_seconds"`
+	ArchivedAt          *time.Time     `db:"archived_at"`
+	LastActivityAt      *time.Time     `db:"last_activity_at"`
+	CreatedAt           time.Time      `db:"created_at"`
+	UpdatedAt           *time.Time     `db:"updated_at"`
 }
@@ -0,0 +1,12 @@
+package dbmodel
+
+import "time"
+
+type SessionBan struct {
+	SessionID string    `db:"session_id"`
+	UserID    string    `db:"user_id"`
+	UserName  string    `db:"user_name"`
+	BannedBy  *string   `db:"banned_by"`
+	Reason    *string   `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
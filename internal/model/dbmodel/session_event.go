@@ -0,0 +1,12 @@
+package dbmodel
+
+import "time"
+
+type SessionEvent struct {
+	ID           string    `db:"id"`
+	SessionID    string    `db:"session_id"`
+	ActorID      *string   `db:"actor_id"`
+	Type         string    `db:"event_type"`
+	TargetUserID *string   `db:"target_user_id"`
+	CreatedAt    time.Time `db:"created_at"`
+}
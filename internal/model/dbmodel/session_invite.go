@@ -0,0 +1,13 @@
+package dbmodel
+
+import "time"
+
+type SessionInvite struct {
+	ID        string     `db:"id"`
+	SessionID string     `db:"session_id"`
+	CreatedBy *string    `db:"created_by"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	MaxUses   *int       `db:"max_uses"`
+	Uses      int        `db:"uses"`
+	CreatedAt time.Time  `db:"created_at"`
+}
@@ -0,0 +1,11 @@
+package dbmodel
+
+import "time"
+
+type SessionParticipant struct {
+	SessionID string    `db:"session_id"`
+	UserID    string    `db:"user_id"`
+	UserName  string    `db:"user_name"`
+	Role      string    `db:"role"`
+	JoinedAt  time.Time `db:"joined_at"`
+}
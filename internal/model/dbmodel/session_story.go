@@ -0,0 +1,12 @@
+package dbmodel
+
+import "time"
+
+type SessionStory struct {
+	ID        string    `db:"id"`
+	SessionID string    `db:"session_id"`
+	Title     string    `db:"title"`
+	Position  int       `db:"position"`
+	Estimate  *string   `db:"estimate"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,20 @@
+package dbmodel
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+type SessionTemplate struct {
+	ID           string         `db:"id"`
+	OwnerID      uuid.UUID      `db:"owner_id"`
+	Name         string         `db:"name"`
+	DeckType     string         `db:"deck_type"`
+	AllowEmoji   bool           `db:"allow_emoji"`
+	AutoReveal   bool           `db:"auto_reveal"`
+	TimerSeconds *int           `db:"timer_seconds"`
+	Stories      pq.StringArray `db:"stories"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    *time.Time     `db:"updated_at"`
+}
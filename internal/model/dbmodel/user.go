@@ -3,29 +3,28 @@ package dbmodel
 
 import "time"
 
-type OAuthProviderEnum string
-
-const (
-	Google OAuthProviderEnum = "google"
-	Yandex OAuthProviderEnum = "yandex"
-)
-
 type User struct {
-	ID             string             `db:"id"`
-	SessionID      *string            `db:"session_id"`
-	Name           string             `db:"name"`
-	Email          string             `db:"email"`
-	HashedPassword string             `db:"hashed_password"`
-	IsActive       bool               `db:"is_active"`
-	IsVerified     bool               `db:"is_verified"`
-	IsGuest        bool               `db:"is_guest"`
-	OAuthProvider  *OAuthProviderEnum `db:"oauth_provider"`
-	OAuthID        *string            `db:"oauth_id"`
-	AvatarURL      *string            `db:"avatar_url"`
-	IsCreator      bool               `db:"is_creator"`
-	IsWatcher      bool               `db:"is_watcher"`
-	OnSession      bool               `db:"on_session"`
-	SocketID       *string            `db:"socket_id"`
-	CreatedAt      time.Time          `db:"created_at"`
-	UpdatedAt      *time.Time         `db:"updated_at"`
+	ID               string         `db:"id"`
+	SessionID        *string        `db:"session_id"`
+	Name             string         `db:"name"`
+	Email            string         `db:"email"`
+	HashedPassword   string         `db:"hashed_password"`
+	IsActive         bool           `db:"is_active"`
+	IsVerified       bool           `db:"is_verified"`
+	IsGuest          bool           `db:"is_guest"`
+	Role             string         `db:"role"`
+	OAuthProvider    *OAuthProvider `db:"oauth_provider"`
+	OAuthID          *string        `db:"oauth_id"`
+	AvatarURL        *string        `db:"avatar_url"`
+	IsCreator        bool           `db:"is_creator"`
+	IsWatcher        bool           `db:"is_watcher"`
+	OnSession        bool           `db:"on_session"`
+	SocketID         *string        `db:"socket_id"`
+	CreatedAt        time.Time      `db:"created_at"`
+	UpdatedAt        *time.Time     `db:"updated_at"`
+	TokensValidAfter *time.Time     `db:"tokens_valid_after"`
+	DeletedAt        *time.Time     `db:"deleted_at"`
+	MFASecret        *string        `db:"mfa_secret"`
+	MFAEnabled       bool           `db:"mfa_enabled"`
+	MFALastStep      *int64         `db:"mfa_last_step"`
 }
@@ -0,0 +1,65 @@
+package entitymodel
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// APITokenPrefix отличает персональные токены от JWT в заголовке Authorization
+const APITokenPrefix = "asp_"
+
+// Права персональных токенов
+const (
+	ScopeProfileRead   = "profile:read"
+	ScopeSessionsRead  = "sessions:read"
+	ScopeSessionsWrite = "sessions:write"
+)
+
+var apiTokenScopes = []string{
+	ScopeProfileRead,
+	ScopeSessionsRead,
+	ScopeSessionsWrite,
+}
+
+// APITokenScopes возвращает список всех прав в порядке объявления
+func APITokenScopes() []string {
+	scopes := make([]string, len(apiTokenScopes))
+	copy(scopes, apiTokenScopes)
+	return scopes
+}
+
+func IsValidAPITokenScope(scope string) bool {
+	for _, known := range apiTokenScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken - персональный токен доступа. Сам токен показывается один раз при создании,
+// хранится только его хеш и начало (TokenPrefix), по которому пользователь узнаёт токен в списке.
+type APIToken struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []string
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	CreatedAt   *time.Time
+}
+
+func (t *APIToken) HasScope(scope string) bool {
+	for _, granted := range t.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *APIToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
@@ -0,0 +1,61 @@
+package entitymodel
+
+// Типы колод (колонка sessions.deck_type)
+const (
+	DeckTypeFibonacci         = "fibonacci"
+	DeckTypeModifiedFibonacci = "modified_fibonacci"
+	DeckTypeTShirt            = "tshirt"
+	DeckTypePowersOfTwo       = "powers_of_two"
+)
+
+// Служебные карты, доступные в любой колоде
+const (
+	VoteValueUnknown = "?"
+	VoteValueCoffee  = "coffee"
+)
+
+var deckCards = map[string][]string{
+	DeckTypeFibonacci:         {"0", "1", "2", "3", "5", "8", "13", "21", "34", "55", "89"},
+	DeckTypeModifiedFibonacci: {"0", "0.5", "1", "2", "3", "5", "8", "13", "20", "40", "100"},
+	DeckTypeTShirt:            {"XS", "S", "M", "L", "XL", "XXL"},
+	DeckTypePowersOfTwo:       {"0", "1", "2", "4", "8", "16", "32", "64"},
+}
+
+// IsValidDeckType проверяет, что колода известна
+func IsValidDeckType(deckType string) bool {
+	_, ok := deckCards[deckType]
+	return ok
+}
+
+// DeckCards возвращает карты колоды вместе со служебными
+func DeckCards(deckType string) []string {
+	cards, ok := deckCards[deckType]
+	if !ok {
+		return nil
+	}
+
+	result := make([]string, 0, len(cards)+2)
+	result = append(result, cards...)
+	return append(result, VoteValueUnknown, VoteValueCoffee)
+}
+
+// IsValidVoteValue проверяет, что карта есть в колоде
+func IsValidVoteValue(deckType, value string) bool {
+	for _, card := range DeckCards(deckType) {
+		if card == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsKnownVoteValue проверяет, что карта есть хотя бы в одной колоде.
+// Используется при валидации запроса, когда колода сессии ещё не известна.
+func IsKnownVoteValue(value string) bool {
+	for deckType := range deckCards {
+		if IsValidVoteValue(deckType, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package entitymodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// oauthProviderNamePattern - допустимое имя провайдера. Набор провайдеров задаётся конфигурацией
+// (см. oauth.Registry), поэтому здесь проверяется только формат имени.
+var oauthProviderNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// OAuthProvider - доменное представление OAuth-провайдера (в нижнем регистре).
+// В БД провайдер хранится в верхнем регистре, см. dbmodel.OAuthProvider.
+type OAuthProvider string
+
+// ParseOAuthProvider приводит строку к OAuthProvider без учёта регистра
+func ParseOAuthProvider(value string) (OAuthProvider, error) {
+	provider := OAuthProvider(strings.ToLower(strings.TrimSpace(value)))
+	if !provider.IsValid() {
+		return "", fmt.Errorf("invalid oauth provider name %q", value)
+	}
+	return provider, nil
+}
+
+func (p OAuthProvider) IsValid() bool {
+	return oauthProviderNamePattern.MatchString(string(p))
+}
+
+func (p OAuthProvider) String() string {
+	return string(p)
+}
+
+func (p OAuthProvider) MarshalJSON() ([]byte, error) {
+	if !p.IsValid() {
+		return nil, fmt.Errorf("invalid oauth provider name %q", string(p))
+	}
+	return json.Marshal(string(p))
+}
+
+func (p *OAuthProvider) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	provider, err := ParseOAuthProvider(value)
+	if err != nil {
+		return err
+	}
+
+	*p = provider
+	return nil
+}
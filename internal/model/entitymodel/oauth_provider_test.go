@@ -0,0 +1,117 @@
+package entitymodel
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseOAuthProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    OAuthProvider
+		wantErr bool
+	}{
+		{name: "google", value: "google", want: "google"},
+		{name: "database google", value: "GOOGLE", want: "google"},
+		{name: "database yandex", value: "YANDEX", want: "yandex"},
+		{name: "surrounding spaces", value: " Yandex ", want: "yandex"},
+		{name: "configured oidc provider", value: "KEYCLOAK", want: "keycloak"},
+		{name: "name with dash and underscore", value: "my-idp_2", want: "my-idp_2"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "invalid name", value: "goo gle", wantErr: true},
+		{name: "starts with digit", value: "1idp", wantErr: true},
+		{name: "too long", value: "a234567890123456789012345678901234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOAuthProvider(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOAuthProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOAuthProvider() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProviderMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider OAuthProvider
+		want     string
+		wantErr  bool
+	}{
+		{name: "google", provider: "google", want: `"google"`},
+		{name: "yandex", provider: "yandex", want: `"yandex"`},
+		{name: "configured oidc provider", provider: "keycloak", want: `"keycloak"`},
+		{name: "uppercase is not a domain value", provider: "GOOGLE", wantErr: true},
+		{name: "empty", provider: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.provider)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProviderUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    OAuthProvider
+		wantErr bool
+	}{
+		{name: "google", data: `"google"`, want: "google"},
+		{name: "database google", data: `"GOOGLE"`, want: "google"},
+		{name: "database yandex", data: `"YANDEX"`, want: "yandex"},
+		{name: "configured oidc provider", data: `"KEYCLOAK"`, want: "keycloak"},
+		{name: "invalid name", data: `"goo gle"`, wantErr: true},
+		{name: "not a string", data: `1`, wantErr: true},
+		{name: "null into value", data: `null`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got OAuthProvider
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProviderJSONNull(t *testing.T) {
+	type holder struct {
+		Provider *OAuthProvider `json:"provider"`
+	}
+
+	data, err := json.Marshal(holder{})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"provider":null}` {
+		t.Errorf("Marshal() = %s, want {\"provider\":null}", data)
+	}
+
+	got := holder{Provider: new(OAuthProvider)}
+	if err := json.Unmarshal([]byte(`{"provider":null}`), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Provider != nil {
+		t.Errorf("Unmarshal() provider = %q, want nil", *got.Provider)
+	}
+}
@@ -0,0 +1,15 @@
+package entitymodel
+
+import "time"
+
+// OAuthState - данные, сохраняемые между /start и /callback OAuth-флоу
+type OAuthState struct {
+	Provider     OAuthProvider
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+func (s *OAuthState) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
@@ -0,0 +1,45 @@
+package entitymodel
+
+// Глобальные роли пользователей (колонка users.role).
+// Роль гостя всегда совпадает с is_guest и меняется вместе с ним при UpgradeGuest.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+	RoleGuest = "guest"
+)
+
+// Роли участника сессии (колонка session_participants.role).
+// Ведущий в сессии один, соведущих может быть несколько: они управляют ходом сессии,
+// но передавать права ведущего и назначать соведущих может только ведущий.
+const (
+	SessionRoleFacilitator   = "facilitator"
+	SessionRoleCoFacilitator = "co_facilitator"
+	SessionRoleVoter         = "voter"
+	SessionRoleObserver      = "observer"
+)
+
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleUser, RoleGuest:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultRole - роль нового пользователя
+func DefaultRole(isGuest bool) string {
+	if isGuest {
+		return RoleGuest
+	}
+	return RoleUser
+}
+
+func IsValidSessionRole(role string) bool {
+	switch role {
+	case SessionRoleFacilitator, SessionRoleCoFacilitator, SessionRoleVoter, SessionRoleObserver:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,6 +1,32 @@
 package entitymodel
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// Способ создания сессии (колонка sessions.created_via)
+const (
+	SessionCreatedViaUser  = "user"
+	SessionCreatedViaGuest = "guest"
+)
+
+// Код для входа в сессию: без похожих символов (0/O, 1/I/L), чтобы его было легко продиктовать
+const (
+	JoinCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	JoinCodeLength   = 6
+)
+
+// NormalizeJoinCode приводит введённый код к виду, в котором он хранится:
+// регистр не важен, пробелы и дефисы игнорируются
+func NormalizeJoinCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+}
 
 type Session struct {
 	ID            string
@@ -12,6 +38,60 @@ type Session struct {
 	AllowEmoji    bool
 	AutoReveal    bool
 	CreatedVia    string
-	CreatedAt     *time.Time
-	UpdatedAt     *time.Time
+	JoinCode      string
+	// PasscodeHash - хеш кода-пароля для входа; nil, если сессия без пароля
+	PasscodeHash *string
+	// AllowedEmailDomains - если список не пуст, войти могут только пользователи с подтверждённым email из этих доменов
+	AllowedEmailDomains []string
+	// TimerSeconds - длительность раунда; nil, если таймер выключен
+	TimerSeconds *int
+	// ArchivedAt - когда сессию убрали в архив; nil для активных
+	ArchivedAt *time.Time
+	// LastActivityAt - время последнего изменения, голоса или события в сессии.
+	// Заполняется только в списке сессий.
+	LastActivityAt *time.Time
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+}
+
+func (s *Session) HasPasscode() bool {
+	return s.PasscodeHash != nil
+}
+
+func (s *Session) IsRestrictedByDomain() bool {
+	return len(s.AllowedEmailDomains) > 0
+}
+
+// IsEmailDomainAllowed сравнивает домен email со списком без учёта регистра
+func (s *Session) IsEmailDomainAllowed(email string) bool {
+	if !s.IsRestrictedByDomain() {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range s.AllowedEmailDomains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEmailDomains приводит домены к нижнему регистру и убирает повторы
+func NormalizeEmailDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	seen := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		normalized = append(normalized, domain)
+	}
+	return normalized
 }
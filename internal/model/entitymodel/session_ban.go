@@ -0,0 +1,13 @@
+package entitymodel
+
+import "time"
+
+// SessionBan - запрет пользователю входить в сессию
+type SessionBan struct {
+	SessionID string
+	UserID    string
+	UserName  string
+	BannedBy  *string
+	Reason    *string
+	CreatedAt *time.Time
+}
@@ -0,0 +1,43 @@
+package entitymodel
+
+import "time"
+
+// Типы событий в истории сессии (колонка session_events.event_type)
+const (
+	SessionEventFacilitatorTransferred = "facilitator_transferred"
+	SessionEventCoFacilitatorAdded     = "co_facilitator_added"
+	SessionEventCoFacilitatorRemoved   = "co_facilitator_removed"
+	SessionEventRoleChanged            = "participant_role_changed"
+	SessionEventCardsRevealed          = "cards_revealed"
+	SessionEventVotesReset             = "votes_reset"
+	SessionEventParticipantKicked      = "participant_kicked"
+	SessionEventParticipantBanned      = "participant_banned"
+	SessionEventParticipantUnbanned    = "participant_unbanned"
+	SessionEventAccessChanged          = "access_changed"
+	SessionEventArchived               = "session_archived"
+	SessionEventUnarchived             = "session_unarchived"
+)
+
+// SessionEvent - запись в истории сессии. ActorID и TargetUserID пустые,
+// если пользователя уже нет или событие не относится к конкретному участнику.
+type SessionEvent struct {
+	ID           string
+	SessionID    string
+	ActorID      *string
+	Type         string
+	TargetUserID *string
+	CreatedAt    *time.Time
+}
+
+// NewSessionEvent создаёт событие от имени участника; target может быть пустым
+func NewSessionEvent(sessionID, actorID, eventType, target string) *SessionEvent {
+	event := &SessionEvent{
+		SessionID: sessionID,
+		ActorID:   &actorID,
+		Type:      eventType,
+	}
+	if target != "" {
+		event.TargetUserID = &target
+	}
+	return event
+}
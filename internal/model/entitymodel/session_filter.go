@@ -0,0 +1,47 @@
+package entitymodel
+
+import "time"
+
+// Статусы сессий в списке
+const (
+	SessionStatusActive   = "active"
+	SessionStatusArchived = "archived"
+	SessionStatusAll      = "all"
+)
+
+// Отношение пользователя к сессиям в списке: созданные им, сессии, где он участник, или все
+const (
+	SessionListRoleCreated      = "created"
+	SessionListRoleParticipated = "participated"
+	SessionListRoleAll          = "all"
+)
+
+// Поля сортировки списка сессий. Последняя активность меняется со временем, поэтому
+// при сортировке по ней сессия может переместиться между уже загруженными страницами.
+const (
+	SessionSortCreatedAt    = "created_at"
+	SessionSortName         = "name"
+	SessionSortLastActivity = "last_activity"
+)
+
+// SessionCursor - позиция последней сессии на странице: значение поля сортировки и id
+type SessionCursor struct {
+	Value string
+	ID    string
+}
+
+// SessionFilter - условия выборки списка сессий пользователя
+type SessionFilter struct {
+	UserID      string
+	Search      string
+	DeckType    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Status      string
+	Role        string
+	Sort        string
+	Desc        bool
+	// After - курсор предыдущей страницы; nil для первой страницы. Count его не учитывает.
+	After *SessionCursor
+	Limit int
+}
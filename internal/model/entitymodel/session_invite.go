@@ -0,0 +1,22 @@
+package entitymodel
+
+import "time"
+
+// SessionInvite - приглашение в сессию по ссылке с необязательными сроком действия и лимитом входов
+type SessionInvite struct {
+	ID        string
+	SessionID string
+	CreatedBy *string
+	ExpiresAt *time.Time
+	MaxUses   *int
+	Uses      int
+	CreatedAt *time.Time
+}
+
+func (i *SessionInvite) IsExpired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+func (i *SessionInvite) IsExhausted() bool {
+	return i.MaxUses != nil && i.Uses >= *i.MaxUses
+}
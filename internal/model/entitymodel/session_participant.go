@@ -0,0 +1,39 @@
+package entitymodel
+
+import "time"
+
+// SessionParticipant - участник сессии и его роль в ней
+type SessionParticipant struct {
+	SessionID string
+	UserID    string
+	UserName  string
+	Role      string
+	JoinedAt  *time.Time
+}
+
+// SessionRoleChange - смена роли участника. From защищает от гонок:
+// изменение применяется, только если роль участника всё ещё From.
+type SessionRoleChange struct {
+	UserID string
+	From   string
+	To     string
+}
+
+func (p *SessionParticipant) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManage - ведущий и соведущие управляют ходом сессии
+func (p *SessionParticipant) CanManage() bool {
+	return p.HasRole(SessionRoleFacilitator, SessionRoleCoFacilitator)
+}
+
+// CanVote - наблюдатели видят ход голосования, но не голосуют
+func (p *SessionParticipant) CanVote() bool {
+	return p.HasRole(SessionRoleFacilitator, SessionRoleCoFacilitator, SessionRoleVoter)
+}
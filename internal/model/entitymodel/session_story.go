@@ -0,0 +1,17 @@
+package entitymodel
+
+import "time"
+
+// SessionStory - задача для оценки в сессии. Estimate пустой, пока задача не оценена.
+type SessionStory struct {
+	ID        string
+	SessionID string
+	Title     string
+	Position  int
+	Estimate  *string
+	CreatedAt *time.Time
+}
+
+func (s *SessionStory) IsEstimated() bool {
+	return s.Estimate != nil
+}
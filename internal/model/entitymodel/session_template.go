@@ -0,0 +1,20 @@
+package entitymodel
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SessionTemplate - сохранённые настройки сессии и список задач по умолчанию
+type SessionTemplate struct {
+	ID           string
+	OwnerID      uuid.UUID
+	Name         string
+	DeckType     string
+	AllowEmoji   bool
+	AutoReveal   bool
+	TimerSeconds *int
+	Stories      []string
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+}
@@ -6,31 +6,33 @@ import (
 	"time"
 )
 
-type OAuthProvider string
-
-const (
-	Google OAuthProvider = "google"
-	Yandex OAuthProvider = "yandex"
-)
+// DeletedUserName - имя-заглушка, которое получает пользователь после удаления аккаунта
+const DeletedUserName = "Удалённый пользователь"
 
 type User struct {
-	ID             uuid.UUID
-	Name           string
-	Email          string
-	HashedPassword string
-	IsActive       bool
-	IsVerified     bool
-	IsGuest        bool
-	OAuthProvider  *OAuthProvider
-	OAuthID        *string
-	AvatarURL      *string
-	SessionID      *string
-	IsCreator      bool
-	IsWatcher      bool
-	OnSession      bool
-	SocketID       *string
-	CreatedAt      *time.Time
-	UpdatedAt      *time.Time
+	ID               uuid.UUID
+	Name             string
+	Email            string
+	HashedPassword   string
+	IsActive         bool
+	IsVerified       bool
+	IsGuest          bool
+	Role             string
+	OAuthProvider    *OAuthProvider
+	OAuthID          *string
+	AvatarURL        *string
+	SessionID        *string
+	IsCreator        bool
+	IsWatcher        bool
+	OnSession        bool
+	SocketID         *string
+	CreatedAt        *time.Time
+	UpdatedAt        *time.Time
+	TokensValidAfter *time.Time
+	DeletedAt        *time.Time
+	MFASecret        *string
+	MFAEnabled       bool
+	MFALastStep      *int64
 }
 
 // MarshalLogObject реализует zapcore.ObjectMarshaler для структурированного логирования.
@@ -41,9 +43,11 @@ func (u *User) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddBool("is_active", u.IsActive)
 	enc.AddBool("is_verified", u.IsVerified)
 	enc.AddBool("is_guest", u.IsGuest)
+	enc.AddString("role", u.Role)
 	enc.AddBool("is_creator", u.IsCreator)
 	enc.AddBool("is_watcher", u.IsWatcher)
 	enc.AddBool("on_session", u.OnSession)
+	enc.AddBool("mfa_enabled", u.MFAEnabled)
 
 	// Поля-указатели
 	if u.HashedPassword != "" {
@@ -70,6 +74,12 @@ func (u *User) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if u.UpdatedAt != nil {
 		enc.AddTime("updated_at", *u.UpdatedAt)
 	}
+	if u.TokensValidAfter != nil {
+		enc.AddTime("tokens_valid_after", *u.TokensValidAfter)
+	}
+	if u.DeletedAt != nil {
+		enc.AddTime("deleted_at", *u.DeletedAt)
+	}
 
 	return nil
 }
@@ -81,3 +91,25 @@ func (u *User) IsOAuthUser() bool {
 func (u *User) IsGuestUser() bool {
 	return u.IsGuest
 }
+
+func (u *User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// IsTokenRevoked проверяет, выдан ли токен до последнего отзыва (сброс пароля и т.п.).
+// iat в JWT хранится с точностью до секунды, поэтому сравниваем с усечённым временем.
+func (u *User) IsTokenRevoked(issuedAt time.Time) bool {
+	if u.TokensValidAfter == nil {
+		return false
+	}
+	return issuedAt.Before(u.TokensValidAfter.Truncate(time.Second))
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"time"
+)
+
+const apiTokenColumns = `
+	id, user_id, name, token_hash, token_prefix, scopes,
+	expires_at, last_used_at, created_at`
+
+type APITokenDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewAPITokenDBRepo(db *sqlx.DB, log *zap.Logger) *APITokenDBRepo {
+	return &APITokenDBRepo{db: db, log: log}
+}
+
+func (repo *APITokenDBRepo) Create(ctx context.Context, token *entitymodel.APIToken) (*entitymodel.APIToken, error) {
+	query := `
+	insert into api_tokens (id, user_id, name, token_hash, token_prefix, scopes, expires_at)
+	values (:id, :user_id, :name, :token_hash, :token_prefix, :scopes, :expires_at)
+	returning created_at
+	`
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	dbToken := converter.APITokenEntityToDB(token)
+
+	rows, err := repo.db.NamedQueryContext(ctx, query, dbToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api token: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&dbToken.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to create api token: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to create api token: %w", err)
+	}
+
+	return converter.APITokenDBToEntity(dbToken), nil
+}
+
+func (repo *APITokenDBRepo) GetByHash(ctx context.Context, tokenHash string) (*entitymodel.APIToken, error) {
+	query := `select` + apiTokenColumns + `
+	from api_tokens
+	where token_hash = $1
+	`
+
+	var token dbmodel.APIToken
+	if err := repo.db.GetContext(ctx, &token, query, tokenHash); err != nil {
+		return nil, err
+	}
+
+	return converter.APITokenDBToEntity(&token), nil
+}
+
+func (repo *APITokenDBRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entitymodel.APIToken, error) {
+	query := `select` + apiTokenColumns + `
+	from api_tokens
+	where user_id = $1
+	order by created_at desc
+	`
+
+	var rows []dbmodel.APIToken
+	if err := repo.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+
+	tokens := make([]*entitymodel.APIToken, 0, len(rows))
+	for i := range rows {
+		tokens = append(tokens, converter.APITokenDBToEntity(&rows[i]))
+	}
+
+	return tokens, nil
+}
+
+func (repo *APITokenDBRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	if err := repo.db.GetContext(ctx, &count, `select count(*) from api_tokens where user_id = $1`, userID); err != nil {
+		return 0, fmt.Errorf("failed to count api tokens: %w", err)
+	}
+
+	return count, nil
+}
+
+// Delete отзывает токен пользователя. Если токен не найден или принадлежит другому пользователю,
+// возвращается sql.ErrNoRows.
+func (repo *APITokenDBRepo) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result, err := repo.db.ExecContext(ctx, `delete from api_tokens where id = $1 and user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete api token: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// TouchLastUsed обновляет last_used_at не чаще раза в interval, чтобы не писать в БД на каждый запрос
+func (repo *APITokenDBRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, interval time.Duration) error {
+	query := `
+	update api_tokens
+	set last_used_at = now()
+	where id = $1
+	  and (last_used_at is null or last_used_at < now() - make_interval(secs => $2))
+	`
+
+	if _, err := repo.db.ExecContext(ctx, query, id, interval.Seconds()); err != nil {
+		return fmt.Errorf("failed to update api token last_used_at: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"errors"
+	"github.com/lib/pq"
+)
+
+// ErrJoinCodeTaken - код входа уже занят другой сессией, нужно сгенерировать новый
+var ErrJoinCodeTaken = errors.New("join code is already taken")
+
+// ErrStoryPositionTaken - позицию задачи одновременно заняла другая вставка, нужно повторить
+var ErrStoryPositionTaken = errors.New("story position is already taken")
+
+const (
+	uniqueViolation = "23505"
+
+	joinCodeIndex      = "ix_sessions_join_code"
+	storyPositionIndex = "ix_session_stories_session_id_position"
+)
+
+// isUniqueViolation проверяет, что запрос нарушил уникальный индекс constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
@@ -4,14 +4,140 @@ import (
 	"backend_go/internal/model/entitymodel"
 	"context"
 	"github.com/google/uuid"
+	"time"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *entitymodel.User) (*entitymodel.User, error)
 	GetByEmail(ctx context.Context, email string) (*entitymodel.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entitymodel.User, error)
+	GetByOAuth(ctx context.Context, provider entitymodel.OAuthProvider, oauthID string) (*entitymodel.User, error)
+	LinkOAuth(ctx context.Context, id uuid.UUID, provider entitymodel.OAuthProvider, oauthID string, avatarURL *string) error
+	MarkVerified(ctx context.Context, id uuid.UUID) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error
+	// RehashPassword заменяет хеш, только если пароль не успели сменить с момента проверки
+	RehashPassword(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
+	UpdateProfile(ctx context.Context, id uuid.UUID, name string, avatarURL *string) error
+	RevokeTokens(ctx context.Context, id uuid.UUID) error
+	Anonymize(ctx context.Context, id uuid.UUID, placeholderName string) error
+	SetMFASecret(ctx context.Context, id uuid.UUID, secret string) error
+	EnableMFA(ctx context.Context, id uuid.UUID) error
+	DisableMFA(ctx context.Context, id uuid.UUID) error
+	UseMFAStep(ctx context.Context, id uuid.UUID, step int64) error
+	UpgradeGuest(ctx context.Context, id uuid.UUID, name, email, hashedPassword string) error
+	SetRole(ctx context.Context, id uuid.UUID, role string) error
 }
 
 type SessionRepository interface {
 	GetByCreator(ctx context.Context, userId string) ([]*entitymodel.Session, error)
+	List(ctx context.Context, filter *entitymodel.SessionFilter) ([]*entitymodel.Session, error)
+	Count(ctx context.Context, filter *entitymodel.SessionFilter) (int, error)
+	GetByID(ctx context.Context, id string) (*entitymodel.Session, error)
+	GetByJoinCode(ctx context.Context, code string) (*entitymodel.Session, error)
+	// Create возвращает ErrJoinCodeTaken, если код входа уже занят
+	Create(ctx context.Context, session *entitymodel.Session, stories []string) (*entitymodel.Session, error)
+	SetJoinCode(ctx context.Context, id, code string) error
+	SetAccess(ctx context.Context, id string, passcodeHash *string, allowedEmailDomains []string, event *entitymodel.SessionEvent) error
+	SetCardsRevealed(ctx context.Context, id string, revealed bool, event *entitymodel.SessionEvent) error
+	ResetVotes(ctx context.Context, id string, event *entitymodel.SessionEvent) error
+	SetArchived(ctx context.Context, id string, archived bool, event *entitymodel.SessionEvent) error
+}
+
+// SessionStoryRepository хранит задачи для оценки в сессии
+type SessionStoryRepository interface {
+	ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionStory, error)
+	Create(ctx context.Context, sessionID, title string) (*entitymodel.SessionStory, error)
+	SetEstimate(ctx context.Context, sessionID, storyID, estimate string) (*entitymodel.SessionStory, error)
+}
+
+// SessionTemplateRepository хранит шаблоны сессий; все методы ограничены владельцем шаблона
+type SessionTemplateRepository interface {
+	Create(ctx context.Context, template *entitymodel.SessionTemplate) (*entitymodel.SessionTemplate, error)
+	Get(ctx context.Context, ownerID uuid.UUID, id string) (*entitymodel.SessionTemplate, error)
+	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entitymodel.SessionTemplate, error)
+	CountByOwner(ctx context.Context, ownerID uuid.UUID) (int, error)
+	Update(ctx context.Context, template *entitymodel.SessionTemplate) (*entitymodel.SessionTemplate, error)
+	Delete(ctx context.Context, ownerID uuid.UUID, id string) error
+}
+
+type SessionEventRepository interface {
+	ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionEvent, error)
+	HasEvent(ctx context.Context, sessionID, userID, eventType string) (bool, error)
+}
+
+// SessionBanRepository хранит запреты на вход в сессию
+type SessionBanRepository interface {
+	Ban(ctx context.Context, ban *entitymodel.SessionBan, event *entitymodel.SessionEvent) (*entitymodel.SessionBan, error)
+	Unban(ctx context.Context, sessionID, userID string, event *entitymodel.SessionEvent) error
+	IsBanned(ctx context.Context, sessionID, userID string) (bool, error)
+	ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionBan, error)
+}
+
+// SessionInviteRepository хранит приглашения в сессии по ссылке
+type SessionInviteRepository interface {
+	Create(ctx context.Context, invite *entitymodel.SessionInvite) (*entitymodel.SessionInvite, error)
+	Get(ctx context.Context, id string) (*entitymodel.SessionInvite, error)
+	ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionInvite, error)
+	Consume(ctx context.Context, id string) (*entitymodel.SessionInvite, error)
+	Release(ctx context.Context, id string) error
+	Delete(ctx context.Context, sessionID, id string) error
+}
+
+// SessionParticipantRepository хранит участников сессий и их роли
+type SessionParticipantRepository interface {
+	// Add добавляет участника; если он уже в сессии, возвращается существующая запись.
+	// Заблокированный в сессии пользователь не добавляется, тогда возвращается sql.ErrNoRows.
+	Add(ctx context.Context, sessionID, userID, role string) (*entitymodel.SessionParticipant, error)
+	Get(ctx context.Context, sessionID, userID string) (*entitymodel.SessionParticipant, error)
+	ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionParticipant, error)
+	ChangeRoles(ctx context.Context, sessionID string, changes []entitymodel.SessionRoleChange, event *entitymodel.SessionEvent) error
+	Remove(ctx context.Context, sessionID, userID string, event *entitymodel.SessionEvent) error
+}
+
+type VoteRepository interface {
+	GetByUser(ctx context.Context, userID string) ([]*entitymodel.Vote, error)
+}
+
+type ReactionRepository interface {
+	GetByFromUser(ctx context.Context, userID string) ([]*entitymodel.Reaction, error)
+	GetByToUser(ctx context.Context, userID string) ([]*entitymodel.Reaction, error)
+}
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error
+	Consume(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+// APITokenRepository хранит персональные токены доступа (только хеши)
+type APITokenRepository interface {
+	Create(ctx context.Context, token *entitymodel.APIToken) (*entitymodel.APIToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*entitymodel.APIToken, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entitymodel.APIToken, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, interval time.Duration) error
+}
+
+type OAuthStateRepository interface {
+	Save(ctx context.Context, state string, data *entitymodel.OAuthState) error
+	Pop(ctx context.Context, state string) (*entitymodel.OAuthState, error)
+}
+
+// LoginAttemptRepository хранит счётчики неудачных попыток входа и временные блокировки
+type LoginAttemptRepository interface {
+	// RegisterFailure увеличивает счётчик ключа и возвращает новое значение.
+	// Окно отсчитывается от первой неудачи, по его истечении счётчик сбрасывается.
+	RegisterFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	// LockedFor возвращает оставшееся время блокировки, 0 - ключ не заблокирован
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// MFARecoveryCodeRepository хранит хеши одноразовых кодов восстановления 2FA
+type MFARecoveryCodeRepository interface {
+	// Replace заменяет все коды пользователя новыми
+	Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	// Consume помечает код использованным; если код не найден или уже использован, возвращает sql.ErrNoRows
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string) error
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+type loginAttemptCounter struct {
+	failures  int
+	expiresAt time.Time
+}
+
+// LoginAttemptMemoryRepo хранит попытки входа в памяти процесса.
+// Подходит для одного инстанса; при нескольких инстансах нужен LoginAttemptRedisRepo.
+type LoginAttemptMemoryRepo struct {
+	mu       sync.Mutex
+	counters map[string]loginAttemptCounter
+	locks    map[string]time.Time
+	log      *zap.Logger
+}
+
+func NewLoginAttemptMemoryRepo(log *zap.Logger) *LoginAttemptMemoryRepo {
+	return &LoginAttemptMemoryRepo{
+		counters: make(map[string]loginAttemptCounter),
+		locks:    make(map[string]time.Time),
+		log:      log,
+	}
+}
+
+func (repo *LoginAttemptMemoryRepo) RegisterFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now()
+	repo.removeExpired(now)
+
+	counter, ok := repo.counters[key]
+	if !ok {
+		counter.expiresAt = now.Add(window)
+	}
+	counter.failures++
+	repo.counters[key] = counter
+
+	return counter.failures, nil
+}
+
+func (repo *LoginAttemptMemoryRepo) Lock(ctx context.Context, key string, duration time.Duration) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	until := time.Now().Add(duration)
+	// Более длинную блокировку не укорачиваем
+	if current, ok := repo.locks[key]; ok && current.After(until) {
+		return nil
+	}
+	repo.locks[key] = until
+
+	return nil
+}
+
+func (repo *LoginAttemptMemoryRepo) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	until, ok := repo.locks[key]
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		delete(repo.locks, key)
+		return 0, nil
+	}
+
+	return remaining, nil
+}
+
+func (repo *LoginAttemptMemoryRepo) Reset(ctx context.Context, key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.counters, key)
+	delete(repo.locks, key)
+
+	return nil
+}
+
+func (repo *LoginAttemptMemoryRepo) removeExpired(now time.Time) {
+	for key, counter := range repo.counters {
+		if now.After(counter.expiresAt) {
+			delete(repo.counters, key)
+		}
+	}
+	for key, until := range repo.locks {
+		if now.After(until) {
+			delete(repo.locks, key)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	loginFailuresKeyPrefix = "login:failures:"
+	loginLockKeyPrefix     = "login:lock:"
+)
+
+// registerFailureScript атомарно увеличивает счётчик и ставит TTL только при первой неудаче
+var registerFailureScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+if failures == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return failures
+`)
+
+// lockScript не даёт укоротить уже стоящую более длинную блокировку
+var lockScript = redis.NewScript(`
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < tonumber(ARGV[1]) then
+	redis.call("SET", KEYS[1], "1", "PX", ARGV[1])
+end
+return 1
+`)
+
+// LoginAttemptRedisRepo хранит попытки входа в Redis, счётчики общие для всех инстансов
+type LoginAttemptRedisRepo struct {
+	client *redis.Client
+	log    *zap.Logger
+}
+
+func NewLoginAttemptRedisRepo(client *redis.Client, log *zap.Logger) *LoginAttemptRedisRepo {
+	return &LoginAttemptRedisRepo{
+		client: client,
+		log:    log,
+	}
+}
+
+func (repo *LoginAttemptRedisRepo) RegisterFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	failures, err := registerFailureScript.Run(ctx, repo.client, []string{loginFailuresKeyPrefix + key}, window.Milliseconds()).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to register login failure: %w", err)
+	}
+	return failures, nil
+}
+
+func (repo *LoginAttemptRedisRepo) Lock(ctx context.Context, key string, duration time.Duration) error {
+	if err := lockScript.Run(ctx, repo.client, []string{loginLockKeyPrefix + key}, duration.Milliseconds()).Err(); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	return nil
+}
+
+func (repo *LoginAttemptRedisRepo) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := repo.client.PTTL(ctx, loginLockKeyPrefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check login lock: %w", err)
+	}
+
+	// Отрицательные значения означают, что ключа нет или у него нет TTL
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (repo *LoginAttemptRedisRepo) Reset(ctx context.Context, key string) error {
+	if err := repo.client.Del(ctx, loginFailuresKeyPrefix+key, loginLockKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type MFARecoveryCodeDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewMFARecoveryCodeDBRepo(db *sqlx.DB, log *zap.Logger) *MFARecoveryCodeDBRepo {
+	return &MFARecoveryCodeDBRepo{db: db, log: log}
+}
+
+func (repo *MFARecoveryCodeDBRepo) Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `delete from mfa_recovery_codes where user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete mfa recovery codes: %w", err)
+	}
+
+	for _, codeHash := range codeHashes {
+		_, err := tx.ExecContext(ctx, `
+		insert into mfa_recovery_codes (user_id, code_hash)
+		values ($1, $2)
+		`, userID, codeHash)
+		if err != nil {
+			return fmt.Errorf("failed to create mfa recovery code: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *MFARecoveryCodeDBRepo) Consume(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query := `
+	update mfa_recovery_codes
+	set used_at = now()
+	where user_id = $1 and code_hash = $2 and used_at is null
+	`
+
+	result, err := repo.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return fmt.Errorf("failed to consume mfa recovery code: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
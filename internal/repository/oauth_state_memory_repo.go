@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+type OAuthStateMemoryRepo struct {
+	mu     sync.Mutex
+	states map[string]entitymodel.OAuthState
+	log    *zap.Logger
+}
+
+func NewOAuthStateMemoryRepo(log *zap.Logger) *OAuthStateMemoryRepo {
+	return &OAuthStateMemoryRepo{
+		states: make(map[string]entitymodel.OAuthState),
+		log:    log,
+	}
+}
+
+func (repo *OAuthStateMemoryRepo) Save(ctx context.Context, state string, data *entitymodel.OAuthState) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.removeExpired(time.Now())
+	repo.states[state] = *data
+
+	return nil
+}
+
+// Pop возвращает и удаляет state, чтобы его нельзя было использовать повторно
+func (repo *OAuthStateMemoryRepo) Pop(ctx context.Context, state string) (*entitymodel.OAuthState, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	data, ok := repo.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(repo.states, state)
+
+	if data.IsExpired() {
+		return nil, nil
+	}
+
+	return &data, nil
+}
+
+func (repo *OAuthStateMemoryRepo) removeExpired(now time.Time) {
+	for key, data := range repo.states {
+		if now.After(data.ExpiresAt) {
+			delete(repo.states, key)
+		}
+	}
+}
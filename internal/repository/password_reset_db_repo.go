@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"time"
+)
+
+type PasswordResetDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewPasswordResetDBRepo(db *sqlx.DB, log *zap.Logger) *PasswordResetDBRepo {
+	return &PasswordResetDBRepo{db: db, log: log}
+}
+
+func (repo *PasswordResetDBRepo) Create(
+	ctx context.Context,
+	userID uuid.UUID,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	query := `
+	insert into password_reset_tokens (user_id, token_hash, expires_at)
+	values ($1, $2, $3)
+	`
+
+	if _, err := repo.db.ExecContext(ctx, query, userID, tokenHash, expiresAt); err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume атомарно помечает действующий токен использованным и возвращает id пользователя.
+// Остальные неиспользованные токены пользователя тоже гасятся.
+// Если токен не найден, истёк или уже использован, возвращается sql.ErrNoRows.
+func (repo *PasswordResetDBRepo) Consume(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	query := `
+	update password_reset_tokens
+	set used_at = now()
+	where token_hash = $1
+	  and used_at is null
+	  and expires_at > now()
+	returning user_id
+	`
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var userID uuid.UUID
+	if err := tx.GetContext(ctx, &userID, query, tokenHash); err != nil {
+		return uuid.Nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	update password_reset_tokens
+	set used_at = now()
+	where user_id = $1 and used_at is null
+	`, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type ReactionDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewReactionDBRepo(db *sqlx.DB, logger *zap.Logger) *ReactionDBRepo {
+	return &ReactionDBRepo{
+		db:  db,
+		log: logger,
+	}
+}
+
+func (r *ReactionDBRepo) GetByFromUser(ctx context.Context, userID string) ([]*entitymodel.Reaction, error) {
+	return r.list(ctx, `
+	select id, session_id, from_user_id, to_user_id, emoji, created_at
+		from reactions
+		where from_user_id = $1
+		order by created_at
+	`, userID)
+}
+
+func (r *ReactionDBRepo) GetByToUser(ctx context.Context, userID string) ([]*entitymodel.Reaction, error) {
+	return r.list(ctx, `
+	select id, session_id, from_user_id, to_user_id, emoji, created_at
+		from reactions
+		where to_user_id = $1
+		order by created_at
+	`, userID)
+}
+
+func (r *ReactionDBRepo) list(ctx context.Context, query string, args ...interface{}) ([]*entitymodel.Reaction, error) {
+	var reactions []dbmodel.Reaction
+	if err := r.db.SelectContext(ctx, &reactions, query, args...); err != nil {
+		r.log.Debug("error message", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]*entitymodel.Reaction, 0, len(reactions))
+	for i := range reactions {
+		result = append(result, converter.ReactionDBToEntity(&reactions[i]))
+	}
+
+	return result, nil
+}
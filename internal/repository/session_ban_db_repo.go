@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type SessionBanDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewSessionBanDBRepo(db *sqlx.DB, log *zap.Logger) *SessionBanDBRepo {
+	return &SessionBanDBRepo{db: db, log: log}
+}
+
+// Ban запрещает пользователю вход в сессию и удаляет его из участников вместе с голосом.
+// Если пользователя не существует, возвращается sql.ErrNoRows.
+func (repo *SessionBanDBRepo) Ban(
+	ctx context.Context,
+	ban *entitymodel.SessionBan,
+	event *entitymodel.SessionEvent,
+) (*entitymodel.SessionBan, error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	insert into session_bans (session_id, user_id, banned_by, reason)
+	select $1, id, $3, $4
+	from users
+	where id = $2
+	on conflict (session_id, user_id) do update
+	set banned_by = excluded.banned_by,
+	    reason = excluded.reason
+	`, ban.SessionID, ban.UserID, ban.BannedBy, ban.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ban session participant: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	if _, err := removeParticipant(ctx, tx, ban.SessionID, ban.UserID); err != nil {
+		return nil, err
+	}
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	var saved dbmodel.SessionBan
+	err = tx.GetContext(ctx, &saved, `
+	select b.session_id, b.user_id, u.name as user_name, b.banned_by, b.reason, b.created_at
+	from session_bans b
+	join users u on u.id = b.user_id
+	where b.session_id = $1 and b.user_id = $2
+	`, ban.SessionID, ban.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session ban: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return converter.SessionBanDBToEntity(&saved), nil
+}
+
+// Unban снимает запрет; если запрета нет, возвращается sql.ErrNoRows
+func (repo *SessionBanDBRepo) Unban(ctx context.Context, sessionID, userID string, event *entitymodel.SessionEvent) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	delete from session_bans
+	where session_id = $1 and user_id = $2
+	`, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unban session participant: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *SessionBanDBRepo) IsBanned(ctx context.Context, sessionID, userID string) (bool, error) {
+	var banned bool
+	err := repo.db.GetContext(ctx, &banned, `
+	select exists (
+		select 1 from session_bans where session_id = $1 and user_id = $2
+	)
+	`, sessionID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session ban: %w", err)
+	}
+
+	return banned, nil
+}
+
+func (repo *SessionBanDBRepo) ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionBan, error) {
+	query := `
+	select b.session_id, b.user_id, u.name as user_name, b.banned_by, b.reason, b.created_at
+	from session_bans b
+	join users u on u.id = b.user_id
+	where b.session_id = $1
+	order by b.created_at
+	`
+
+	var rows []dbmodel.SessionBan
+	if err := repo.db.SelectContext(ctx, &rows, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to list session bans: %w", err)
+	}
+
+	bans := make([]*entitymodel.SessionBan, 0, len(rows))
+	for i := range rows {
+		bans = append(bans, converter.SessionBanDBToEntity(&rows[i]))
+	}
+
+	return bans, nil
+}
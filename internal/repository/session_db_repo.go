@@ -1,12 +1,33 @@
 package repository
 
 import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
 	"backend_go/internal/model/entitymodel"
 	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
+	"strings"
 )
 
+// likeEscaper экранирует спецсимволы LIKE, чтобы поиск шёл по подстроке как есть
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+const sessionColumns = `id, name, deck_type, coalesce(cards_revealed, false) as cards_revealed,
+	creator_id, creator_name, created_at, updated_at, allow_emoji, auto_reveal, created_via,
+	join_code, passcode_hash, allowed_email_domains, timer_seconds, archived_at`
+
+// sessionLastActivity - последняя активность в сессии: изменение самой сессии, голос или событие истории
+const sessionLastActivity = `greatest(
+		coalesce(updated_at, created_at),
+		(select max(coalesce(v.updated_at, v.created_at)) from votes v where v.session_id = sessions.id),
+		(select max(e.created_at) from session_events e where e.session_id = sessions.id)
+	)`
+
 type SessionDBRepo struct {
 	db  *sqlx.DB
 	log *zap.Logger
@@ -21,11 +42,10 @@ func NewSessionDBRepo(db *sqlx.DB, logger *zap.Logger) *SessionDBRepo {
 
 func (r *SessionDBRepo) GetByCreator(ctx context.Context, userId string) ([]*entitymodel.Session, error) {
 	query := `
-	select id, name, deck_type, cards_revealed, 
-	       creator_id, creator_name, created_at, 
-	       updated_at, allow_emoji, auto_reveal, created_via
+	select ` + sessionColumns + `
 		from sessions
 		where creator_id = $1
+		order by created_at, id
 	`
 
 	rows, err := r.db.QueryxContext(ctx, query, userId)
@@ -36,12 +56,12 @@ func (r *SessionDBRepo) GetByCreator(ctx context.Context, userId string) ([]*ent
 
 	sessions := make([]*entitymodel.Session, 0)
 	for rows.Next() {
-		var session entitymodel.Session
+		var session dbmodel.Session
 		if err := rows.StructScan(&session); err != nil {
 			r.log.Debug("error message", zap.Error(err))
 			return nil, err
 		}
-		sessions = append(sessions, &session)
+		sessions = append(sessions, converter.SessionDBToEntity(&session))
 	}
 
 	if err := rows.Err(); err != nil {
@@ -51,3 +71,369 @@ func (r *SessionDBRepo) GetByCreator(ctx context.Context, userId string) ([]*ent
 
 	return sessions, nil
 }
+
+// List возвращает страницу сессий пользователя по фильтру, начиная после filter.After
+func (r *SessionDBRepo) List(ctx context.Context, filter *entitymodel.SessionFilter) ([]*entitymodel.Session, error) {
+	where, args := sessionFilterWhere(filter)
+
+	column := "created_at"
+	cursorValue := "$%d::timestamptz"
+	switch filter.Sort {
+	case entitymodel.SessionSortName:
+		column = "name"
+		cursorValue = "$%d"
+	case entitymodel.SessionSortLastActivity:
+		column = "last_activity_at"
+	}
+	direction, op := "asc", ">"
+	if filter.Desc {
+		direction, op = "desc", "<"
+	}
+
+	// Курсор применяется к внешнему запросу, чтобы можно было сравнивать по вычисляемой last_activity_at;
+	// сама last_activity_at считается только для сессий, прошедших фильтр
+	cursor := "true"
+	if filter.After != nil {
+		args = append(args, filter.After.Value, filter.After.ID)
+		cursor = fmt.Sprintf("(%s, id) %s ("+cursorValue+", $%d::uuid)", column, op, len(args)-1, len(args))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+	select * from (
+		select `+sessionColumns+`, `+sessionLastActivity+` as last_activity_at
+		from sessions
+		where %s
+	) s
+		where %s
+		order by %s %s, id %s
+		limit $%d
+	`, strings.Join(where, " and "), cursor, column, direction, direction, len(args))
+
+	var rows []dbmodel.Session
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*entitymodel.Session, 0, len(rows))
+	for i := range rows {
+		sessions = append(sessions, converter.SessionDBToEntity(&rows[i]))
+	}
+
+	return sessions, nil
+}
+
+// Count возвращает число сессий по фильтру без учёта курсора и лимита
+func (r *SessionDBRepo) Count(ctx context.Context, filter *entitymodel.SessionFilter) (int, error) {
+	where, args := sessionFilterWhere(filter)
+
+	var count int
+	query := `select count(*) from sessions where ` + strings.Join(where, " and ")
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to count sessions: %w", err)
+	}
+
+	return count, nil
+}
+
+// sessionFilterWhere собирает условия фильтра; значения передаются только параметрами.
+// Сессии пользователя выбираются объединением по creator_id и session_participants.user_id:
+// каждая ветка идёт по своему индексу, а не сканирует всю таблицу sessions.
+func sessionFilterWhere(filter *entitymodel.SessionFilter) ([]string, []any) {
+	const created = `select id from sessions where creator_id = $1`
+	const participated = `select session_id from session_participants where user_id = $1`
+
+	var where []string
+	switch filter.Role {
+	case entitymodel.SessionListRoleCreated:
+		where = []string{"creator_id = $1"}
+	case entitymodel.SessionListRoleParticipated:
+		where = []string{"id in (" + participated + ")", "creator_id <> $1"}
+	default:
+		where = []string{"id in (" + created + " union " + participated + ")"}
+	}
+	args := []any{filter.UserID}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Search != "" {
+		add(`name ilike $%d escape '\'`, "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+	if filter.DeckType != "" {
+		add("deck_type = $%d", filter.DeckType)
+	}
+	if filter.CreatedFrom != nil {
+		add("created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		add("created_at <= $%d", *filter.CreatedTo)
+	}
+
+	switch filter.Status {
+	case entitymodel.SessionStatusActive:
+		where = append(where, "archived_at is null")
+	case entitymodel.SessionStatusArchived:
+		where = append(where, "archived_at is not null")
+	}
+
+	return where, args
+}
+
+func (r *SessionDBRepo) GetByID(ctx context.Context, id string) (*entitymodel.Session, error) {
+	query := `
+	select ` + sessionColumns + `
+		from sessions
+		where id = $1
+	`
+
+	var session dbmodel.Session
+	if err := r.db.GetContext(ctx, &session, query, id); err != nil {
+		return nil, err
+	}
+
+	return converter.SessionDBToEntity(&session), nil
+}
+
+// GetByJoinCode ищет сессию по коду входа, код должен быть уже нормализован
+func (r *SessionDBRepo) GetByJoinCode(ctx context.Context, code string) (*entitymodel.Session, error) {
+	query := `
+	select ` + sessionColumns + `
+		from sessions
+		where join_code = $1
+	`
+
+	var session dbmodel.Session
+	if err := r.db.GetContext(ctx, &session, query, code); err != nil {
+		return nil, err
+	}
+
+	return converter.SessionDBToEntity(&session), nil
+}
+
+// SetJoinCode заменяет код входа, старый код сразу перестаёт работать.
+// Если сессии нет, возвращается sql.ErrNoRows, если код занят - ErrJoinCodeTaken.
+func (r *SessionDBRepo) SetJoinCode(ctx context.Context, id, code string) error {
+	result, err := r.db.ExecContext(ctx, `
+	update sessions
+	set join_code = $2,
+	    updated_at = now()
+	where id = $1
+	`, id, code)
+	if isUniqueViolation(err, joinCodeIndex) {
+		return ErrJoinCodeTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update session join code: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetAccess сохраняет ограничения входа и записывает событие в историю.
+// Если сессии нет, возвращается sql.ErrNoRows.
+func (r *SessionDBRepo) SetAccess(
+	ctx context.Context,
+	id string,
+	passcodeHash *string,
+	allowedEmailDomains []string,
+	event *entitymodel.SessionEvent,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	update sessions
+	set passcode_hash = $2,
+	    allowed_email_domains = $3,
+	    updated_at = now()
+	where id = $1
+	`, id, passcodeHash, pq.StringArray(allowedEmailDomains))
+	if err != nil {
+		return fmt.Errorf("failed to update session access: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetArchived убирает сессию в архив или возвращает из него и записывает событие в историю.
+// Если сессии нет, возвращается sql.ErrNoRows.
+func (r *SessionDBRepo) SetArchived(ctx context.Context, id string, archived bool, event *entitymodel.SessionEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	update sessions
+	set archived_at = case when $2 then coalesce(archived_at, now()) end,
+	    updated_at = now()
+	where id = $1
+	`, id, archived)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetCardsRevealed вскрывает или скрывает карты и записывает событие в историю.
+// Если сессии нет, возвращается sql.ErrNoRows.
+func (r *SessionDBRepo) SetCardsRevealed(
+	ctx context.Context,
+	id string,
+	revealed bool,
+	event *entitymodel.SessionEvent,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setCardsRevealed(ctx, tx, id, revealed); err != nil {
+		return err
+	}
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResetVotes удаляет голоса текущего раунда, скрывает карты и записывает событие в историю
+func (r *SessionDBRepo) ResetVotes(ctx context.Context, id string, event *entitymodel.SessionEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setCardsRevealed(ctx, tx, id, false); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `delete from votes where session_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to reset votes: %w", err)
+	}
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setCardsRevealed(ctx context.Context, tx *sqlx.Tx, id string, revealed bool) error {
+	result, err := tx.ExecContext(ctx, `
+	update sessions
+	set cards_revealed = $2,
+	    updated_at = now()
+	where id = $1
+	`, id, revealed)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Create создаёт сессию с начальным списком задач и делает создателя её ведущим
+func (r *SessionDBRepo) Create(
+	ctx context.Context,
+	session *entitymodel.Session,
+	stories []string,
+) (*entitymodel.Session, error) {
+	query := `
+	insert into sessions (
+		id, name, deck_type, cards_revealed, creator_id, creator_name,
+		allow_emoji, auto_reveal, created_via, join_code, passcode_hash, allowed_email_domains, timer_seconds
+	) values (:id, :name, :deck_type, :cards_revealed, :creator_id, :creator_name,
+		:allow_emoji, :auto_reveal, :created_via, :join_code, :passcode_hash, :allowed_email_domains, :timer_seconds)
+	returning created_at
+	`
+
+	if session.ID == "" {
+		session.ID = uuid.New().String()
+	}
+
+	dbSession := converter.SessionEntityToDB(session)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	insert, args, err := tx.BindNamed(query, dbSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := tx.GetContext(ctx, &dbSession.CreatedAt, insert, args...); err != nil {
+		if isUniqueViolation(err, joinCodeIndex) {
+			return nil, ErrJoinCodeTaken
+		}
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	insert into session_participants (session_id, user_id, role)
+	values ($1, $2, $3)
+	`, dbSession.ID, dbSession.CreatorID, entitymodel.SessionRoleFacilitator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add session facilitator: %w", err)
+	}
+
+	if err := insertSessionStories(ctx, tx, dbSession.ID, stories); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return converter.SessionDBToEntity(dbSession), nil
+}
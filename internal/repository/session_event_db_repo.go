@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type SessionEventDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewSessionEventDBRepo(db *sqlx.DB, log *zap.Logger) *SessionEventDBRepo {
+	return &SessionEventDBRepo{db: db, log: log}
+}
+
+func (repo *SessionEventDBRepo) ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionEvent, error) {
+	query := `
+	select id, session_id, actor_id, event_type, target_user_id, created_at
+	from session_events
+	where session_id = $1
+	order by created_at, id
+	`
+
+	var rows []dbmodel.SessionEvent
+	if err := repo.db.SelectContext(ctx, &rows, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to list session events: %w", err)
+	}
+
+	events := make([]*entitymodel.SessionEvent, 0, len(rows))
+	for i := range rows {
+		events = append(events, converter.SessionEventDBToEntity(&rows[i]))
+	}
+
+	return events, nil
+}
+
+// HasEvent проверяет, было ли в сессии событие указанного типа над пользователем
+func (repo *SessionEventDBRepo) HasEvent(ctx context.Context, sessionID, userID, eventType string) (bool, error) {
+	var exists bool
+	err := repo.db.GetContext(ctx, &exists, `
+	select exists (
+		select 1 from session_events
+		where session_id = $1 and target_user_id = $2 and event_type = $3
+	)
+	`, sessionID, userID, eventType)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session event: %w", err)
+	}
+
+	return exists, nil
+}
+
+// insertSessionEvent пишет событие в той же транзакции, что и само изменение,
+// чтобы история не расходилась с состоянием сессии
+func insertSessionEvent(ctx context.Context, tx *sqlx.Tx, event *entitymodel.SessionEvent) error {
+	_, err := tx.ExecContext(ctx, `
+	insert into session_events (session_id, actor_id, event_type, target_user_id)
+	values ($1, $2, $3, $4)
+	`, event.SessionID, event.ActorID, event.Type, event.TargetUserID)
+	if err != nil {
+		return fmt.Errorf("failed to record session event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const sessionInviteColumns = `id, session_id, created_by, expires_at, max_uses, uses, created_at`
+
+type SessionInviteDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewSessionInviteDBRepo(db *sqlx.DB, log *zap.Logger) *SessionInviteDBRepo {
+	return &SessionInviteDBRepo{db: db, log: log}
+}
+
+func (repo *SessionInviteDBRepo) Create(ctx context.Context, invite *entitymodel.SessionInvite) (*entitymodel.SessionInvite, error) {
+	var saved dbmodel.SessionInvite
+	err := repo.db.GetContext(ctx, &saved, `
+	insert into session_invites (session_id, created_by, expires_at, max_uses)
+	values ($1, $2, $3, $4)
+	returning `+sessionInviteColumns,
+		invite.SessionID, invite.CreatedBy, invite.ExpiresAt, invite.MaxUses,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session invite: %w", err)
+	}
+
+	return converter.SessionInviteDBToEntity(&saved), nil
+}
+
+func (repo *SessionInviteDBRepo) Get(ctx context.Context, id string) (*entitymodel.SessionInvite, error) {
+	var invite dbmodel.SessionInvite
+	err := repo.db.GetContext(ctx, &invite, `
+	select `+sessionInviteColumns+`
+	from session_invites
+	where id = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SessionInviteDBToEntity(&invite), nil
+}
+
+func (repo *SessionInviteDBRepo) ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionInvite, error) {
+	var rows []dbmodel.SessionInvite
+	err := repo.db.SelectContext(ctx, &rows, `
+	select `+sessionInviteColumns+`
+	from session_invites
+	where session_id = $1
+	order by created_at
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session invites: %w", err)
+	}
+
+	invites := make([]*entitymodel.SessionInvite, 0, len(rows))
+	for i := range rows {
+		invites = append(invites, converter.SessionInviteDBToEntity(&rows[i]))
+	}
+
+	return invites, nil
+}
+
+// Consume засчитывает вход по приглашению одним запросом, чтобы параллельные входы не превысили лимит.
+// Если приглашения нет, оно истекло или исчерпано, возвращается sql.ErrNoRows.
+func (repo *SessionInviteDBRepo) Consume(ctx context.Context, id string) (*entitymodel.SessionInvite, error) {
+	var invite dbmodel.SessionInvite
+	err := repo.db.GetContext(ctx, &invite, `
+	update session_invites
+	set uses = uses + 1
+	where id = $1
+	  and (expires_at is null or expires_at > now())
+	  and (max_uses is null or uses < max_uses)
+	returning `+sessionInviteColumns,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SessionInviteDBToEntity(&invite), nil
+}
+
+// Release возвращает вход, засчитанный Consume, если войти в сессию так и не удалось
+func (repo *SessionInviteDBRepo) Release(ctx context.Context, id string) error {
+	_, err := repo.db.ExecContext(ctx, `
+	update session_invites
+	set uses = uses - 1
+	where id = $1 and uses > 0
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to release session invite: %w", err)
+	}
+
+	return nil
+}
+
+// Delete отзывает приглашение; если в сессии его нет, возвращается sql.ErrNoRows
+func (repo *SessionInviteDBRepo) Delete(ctx context.Context, sessionID, id string) error {
+	result, err := repo.db.ExecContext(ctx, `
+	delete from session_invites
+	where id = $1 and session_id = $2
+	`, id, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session invite: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const sessionParticipantColumns = `
+	p.session_id, p.user_id, u.name as user_name, p.role, p.joined_at`
+
+type SessionParticipantDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewSessionParticipantDBRepo(db *sqlx.DB, log *zap.Logger) *SessionParticipantDBRepo {
+	return &SessionParticipantDBRepo{db: db, log: log}
+}
+
+func (repo *SessionParticipantDBRepo) Add(
+	ctx context.Context,
+	sessionID, userID, role string,
+) (*entitymodel.SessionParticipant, error) {
+	// Заблокированного пользователя не добавляем, даже если блокировка появилась после проверки в сервисе
+	query := `
+	insert into session_participants (session_id, user_id, role)
+	select $1, $2, $3
+	where not exists (
+		select 1 from session_bans where session_id = $1 and user_id = $2
+	)
+	on conflict (session_id, user_id) do nothing
+	`
+
+	if _, err := repo.db.ExecContext(ctx, query, sessionID, userID, role); err != nil {
+		return nil, fmt.Errorf("failed to add session participant: %w", err)
+	}
+
+	return repo.Get(ctx, sessionID, userID)
+}
+
+func (repo *SessionParticipantDBRepo) Get(ctx context.Context, sessionID, userID string) (*entitymodel.SessionParticipant, error) {
+	query := `select` + sessionParticipantColumns + `
+	from session_participants p
+	join users u on u.id = p.user_id
+	where p.session_id = $1 and p.user_id = $2
+	`
+
+	var participant dbmodel.SessionParticipant
+	if err := repo.db.GetContext(ctx, &participant, query, sessionID, userID); err != nil {
+		return nil, err
+	}
+
+	return converter.SessionParticipantDBToEntity(&participant), nil
+}
+
+func (repo *SessionParticipantDBRepo) ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionParticipant, error) {
+	query := `select` + sessionParticipantColumns + `
+	from session_participants p
+	join users u on u.id = p.user_id
+	where p.session_id = $1
+	order by p.joined_at
+	`
+
+	var rows []dbmodel.SessionParticipant
+	if err := repo.db.SelectContext(ctx, &rows, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to list session participants: %w", err)
+	}
+
+	participants := make([]*entitymodel.SessionParticipant, 0, len(rows))
+	for i := range rows {
+		participants = append(participants, converter.SessionParticipantDBToEntity(&rows[i]))
+	}
+
+	return participants, nil
+}
+
+// ChangeRoles атомарно применяет смены ролей в указанном порядке и записывает событие в историю.
+// Если какого-то участника нет или его роль уже изменилась, ничего не применяется
+// и возвращается sql.ErrNoRows.
+func (repo *SessionParticipantDBRepo) ChangeRoles(
+	ctx context.Context,
+	sessionID string,
+	changes []entitymodel.SessionRoleChange,
+	event *entitymodel.SessionEvent,
+) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, change := range changes {
+		result, err := tx.ExecContext(ctx, `
+		update session_participants
+		set role = $4
+		where session_id = $1 and user_id = $2 and role = $3
+		`, sessionID, change.UserID, change.From, change.To)
+		if err != nil {
+			return fmt.Errorf("failed to update session participant role: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Remove удаляет участника из сессии вместе с его голосом и записывает событие в историю.
+// Ведущего удалить нельзя: для него, как и для отсутствующего участника, возвращается sql.ErrNoRows.
+func (repo *SessionParticipantDBRepo) Remove(
+	ctx context.Context,
+	sessionID, userID string,
+	event *entitymodel.SessionEvent,
+) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	removed, err := removeParticipant(ctx, tx, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return sql.ErrNoRows
+	}
+
+	if err := insertSessionEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// removeParticipant удаляет участника (кроме ведущего) и его голос.
+// Возвращает false, если удалять было некого.
+func removeParticipant(ctx context.Context, tx *sqlx.Tx, sessionID, userID string) (bool, error) {
+	result, err := tx.ExecContext(ctx, `
+	delete from session_participants
+	where session_id = $1 and user_id = $2 and role <> $3
+	`, sessionID, userID, entitymodel.SessionRoleFacilitator)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove session participant: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `delete from votes where session_id = $1 and user_id = $2`, sessionID, userID); err != nil {
+		return false, fmt.Errorf("failed to remove participant vote: %w", err)
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const sessionStoryColumns = `id, session_id, title, position, estimate, created_at`
+
+type SessionStoryDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewSessionStoryDBRepo(db *sqlx.DB, log *zap.Logger) *SessionStoryDBRepo {
+	return &SessionStoryDBRepo{db: db, log: log}
+}
+
+func (repo *SessionStoryDBRepo) ListBySession(ctx context.Context, sessionID string) ([]*entitymodel.SessionStory, error) {
+	var rows []dbmodel.SessionStory
+	err := repo.db.SelectContext(ctx, &rows, `
+	select `+sessionStoryColumns+`
+	from session_stories
+	where session_id = $1
+	order by position
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session stories: %w", err)
+	}
+
+	stories := make([]*entitymodel.SessionStory, 0, len(rows))
+	for i := range rows {
+		stories = append(stories, converter.SessionStoryDBToEntity(&rows[i]))
+	}
+
+	return stories, nil
+}
+
+// Create добавляет задачу в конец списка.
+// Если позицию одновременно заняла другая задача, возвращается ErrStoryPositionTaken.
+func (repo *SessionStoryDBRepo) Create(ctx context.Context, sessionID, title string) (*entitymodel.SessionStory, error) {
+	var story dbmodel.SessionStory
+	err := repo.db.GetContext(ctx, &story, `
+	insert into session_stories (session_id, title, position)
+	select $1, $2, coalesce(max(position), 0) + 1
+	from session_stories
+	where session_id = $1
+	returning `+sessionStoryColumns,
+		sessionID, title,
+	)
+	if isUniqueViolation(err, storyPositionIndex) {
+		return nil, ErrStoryPositionTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session story: %w", err)
+	}
+
+	return converter.SessionStoryDBToEntity(&story), nil
+}
+
+// SetEstimate сохраняет итоговую оценку; если задачи в сессии нет, возвращается sql.ErrNoRows
+func (repo *SessionStoryDBRepo) SetEstimate(
+	ctx context.Context,
+	sessionID, storyID, estimate string,
+) (*entitymodel.SessionStory, error) {
+	var story dbmodel.SessionStory
+	err := repo.db.GetContext(ctx, &story, `
+	update session_stories
+	set estimate = $3
+	where id = $2 and session_id = $1
+	returning `+sessionStoryColumns,
+		sessionID, storyID, estimate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SessionStoryDBToEntity(&story), nil
+}
+
+// insertSessionStories добавляет задачи по порядку; вызывается в транзакции создания сессии
+func insertSessionStories(ctx context.Context, tx *sqlx.Tx, sessionID string, titles []string) error {
+	for i, title := range titles {
+		_, err := tx.ExecContext(ctx, `
+		insert into session_stories (session_id, title, position)
+		values ($1, $2, $3)
+		`, sessionID, title, i+1)
+		if err != nil {
+			return fmt.Errorf("failed to create session story: %w", err)
+		}
+	}
+	return nil
+}
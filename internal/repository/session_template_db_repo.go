@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const sessionTemplateColumns = `id, owner_id, name, deck_type, allow_emoji, auto_reveal, timer_seconds, stories,
+	created_at, updated_at`
+
+type SessionTemplateDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewSessionTemplateDBRepo(db *sqlx.DB, log *zap.Logger) *SessionTemplateDBRepo {
+	return &SessionTemplateDBRepo{db: db, log: log}
+}
+
+func (repo *SessionTemplateDBRepo) Create(
+	ctx context.Context,
+	template *entitymodel.SessionTemplate,
+) (*entitymodel.SessionTemplate, error) {
+	dbTemplate := converter.SessionTemplateEntityToDB(template)
+
+	var saved dbmodel.SessionTemplate
+	err := repo.db.GetContext(ctx, &saved, `
+	insert into session_templates (owner_id, name, deck_type, allow_emoji, auto_reveal, timer_seconds, stories)
+	values ($1, $2, $3, $4, $5, $6, $7)
+	returning `+sessionTemplateColumns,
+		dbTemplate.OwnerID, dbTemplate.Name, dbTemplate.DeckType, dbTemplate.AllowEmoji,
+		dbTemplate.AutoReveal, dbTemplate.TimerSeconds, dbTemplate.Stories,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session template: %w", err)
+	}
+
+	return converter.SessionTemplateDBToEntity(&saved), nil
+}
+
+// Get возвращает шаблон владельца; чужой шаблон не отличается от несуществующего (sql.ErrNoRows)
+func (repo *SessionTemplateDBRepo) Get(ctx context.Context, ownerID uuid.UUID, id string) (*entitymodel.SessionTemplate, error) {
+	var template dbmodel.SessionTemplate
+	err := repo.db.GetContext(ctx, &template, `
+	select `+sessionTemplateColumns+`
+	from session_templates
+	where id = $1 and owner_id = $2
+	`, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SessionTemplateDBToEntity(&template), nil
+}
+
+func (repo *SessionTemplateDBRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entitymodel.SessionTemplate, error) {
+	var rows []dbmodel.SessionTemplate
+	err := repo.db.SelectContext(ctx, &rows, `
+	select `+sessionTemplateColumns+`
+	from session_templates
+	where owner_id = $1
+	order by created_at
+	`, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session templates: %w", err)
+	}
+
+	templates := make([]*entitymodel.SessionTemplate, 0, len(rows))
+	for i := range rows {
+		templates = append(templates, converter.SessionTemplateDBToEntity(&rows[i]))
+	}
+
+	return templates, nil
+}
+
+func (repo *SessionTemplateDBRepo) CountByOwner(ctx context.Context, ownerID uuid.UUID) (int, error) {
+	var count int
+	err := repo.db.GetContext(ctx, &count, `select count(*) from session_templates where owner_id = $1`, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count session templates: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update заменяет настройки шаблона; если у владельца его нет, возвращается sql.ErrNoRows
+func (repo *SessionTemplateDBRepo) Update(
+	ctx context.Context,
+	template *entitymodel.SessionTemplate,
+) (*entitymodel.SessionTemplate, error) {
+	dbTemplate := converter.SessionTemplateEntityToDB(template)
+
+	var saved dbmodel.SessionTemplate
+	err := repo.db.GetContext(ctx, &saved, `
+	update session_templates
+	set name = $3,
+	    deck_type = $4,
+	    allow_emoji = $5,
+	    auto_reveal = $6,
+	    timer_seconds = $7,
+	    stories = $8,
+	    updated_at = now()
+	where id = $1 and owner_id = $2
+	returning `+sessionTemplateColumns,
+		dbTemplate.ID, dbTemplate.OwnerID, dbTemplate.Name, dbTemplate.DeckType,
+		dbTemplate.AllowEmoji, dbTemplate.AutoReveal, dbTemplate.TimerSeconds, dbTemplate.Stories,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SessionTemplateDBToEntity(&saved), nil
+}
+
+// Delete удаляет шаблон владельца; если его нет, возвращается sql.ErrNoRows
+func (repo *SessionTemplateDBRepo) Delete(ctx context.Context, ownerID uuid.UUID, id string) error {
+	result, err := repo.db.ExecContext(ctx, `
+	delete from session_templates
+	where id = $1 and owner_id = $2
+	`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session template: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
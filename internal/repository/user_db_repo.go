@@ -12,6 +12,15 @@ import (
 	"go.uber.org/zap"
 )
 
+// userColumns - набор колонок для выборки пользователя.
+// email и hashed_password могут быть NULL у OAuth-пользователей и гостей.
+const userColumns = `
+	id, session_id, name, is_creator, socket_id, created_at, updated_at,
+	is_watcher, on_session, coalesce(email, '') as email,
+	coalesce(hashed_password, '') as hashed_password, is_active, is_verified,
+	oauth_provider, oauth_id, avatar_url, is_guest, role, tokens_valid_after, deleted_at,
+	mfa_secret, mfa_enabled, mfa_last_step`
+
 type UserDBRepo struct {
 	db  *sqlx.DB
 	log *zap.Logger
@@ -25,10 +34,17 @@ func (repo *UserDBRepo) Create(ctx context.Context, user *entitymodel.User) (*en
 	query := `
         INSERT INTO users (
             name, email, hashed_password, is_active, is_verified,
-            is_guest, is_creator, is_watcher, on_session
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            is_guest, is_creator, is_watcher, on_session,
+            oauth_provider, oauth_id, avatar_url, role
+        ) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
         RETURNING id, created_at, updated_at`
 
+	if user.Role == "" {
+		user.Role = entitymodel.DefaultRole(user.IsGuest)
+	}
+
+	dbUser := converter.UserEntityToDB(user)
+
 	// Используем sql.NullTime для обработки возможных NULL значений
 	var (
 		id        uuid.UUID
@@ -37,15 +53,19 @@ func (repo *UserDBRepo) Create(ctx context.Context, user *entitymodel.User) (*en
 	)
 
 	err := repo.db.QueryRowContext(ctx, query,
-		user.Name,
-		user.Email,
-		user.HashedPassword,
-		user.IsActive,
-		user.IsVerified,
-		user.IsGuest,
-		user.IsCreator,
-		user.IsWatcher,
-		user.OnSession,
+		dbUser.Name,
+		dbUser.Email,
+		dbUser.HashedPassword,
+		dbUser.IsActive,
+		dbUser.IsVerified,
+		dbUser.IsGuest,
+		dbUser.IsCreator,
+		dbUser.IsWatcher,
+		dbUser.OnSession,
+		dbUser.OAuthProvider,
+		dbUser.OAuthID,
+		dbUser.AvatarURL,
+		dbUser.Role,
 	).Scan(&id, &createdAt, &updatedAt)
 
 	if err != nil {
@@ -69,12 +89,8 @@ func (repo *UserDBRepo) Create(ctx context.Context, user *entitymodel.User) (*en
 }
 
 func (repo *UserDBRepo) GetByEmail(ctx context.Context, email string) (*entitymodel.User, error) {
-
-	query := `
-	select id, session_id, name, is_creator, socket_id, created_at, updated_at, 
-	       is_watcher, on_session, email, hashed_password, is_active, is_verified, 
-	       oauth_provider, oauth_id, avatar_url, is_guest
-    from users
+	query := `select ` + userColumns + `
+	from users
 	where email = $1
 	`
 
@@ -88,11 +104,8 @@ func (repo *UserDBRepo) GetByEmail(ctx context.Context, email string) (*entitymo
 }
 
 func (repo *UserDBRepo) GetByID(ctx context.Context, id uuid.UUID) (*entitymodel.User, error) {
-	query := `
-	select id, session_id, name, is_creator, socket_id, created_at, updated_at, 
-	       is_watcher, on_session, email, hashed_password, is_active, is_verified, 
-	       oauth_provider, oauth_id, avatar_url, is_guest
-    from users
+	query := `select ` + userColumns + `
+	from users
 	where id = $1
 	`
 
@@ -104,3 +117,295 @@ func (repo *UserDBRepo) GetByID(ctx context.Context, id uuid.UUID) (*entitymodel
 
 	return converter.UserDBToEntity(&user), nil
 }
+
+func (repo *UserDBRepo) GetByOAuth(
+	ctx context.Context,
+	provider entitymodel.OAuthProvider,
+	oauthID string,
+) (*entitymodel.User, error) {
+	query := `select ` + userColumns + `
+	from users
+	where oauth_provider = $1 and oauth_id = $2
+	`
+
+	dbProvider := converter.OAuthProviderEntityToDB(provider)
+
+	var user dbmodel.User
+	err := repo.db.GetContext(ctx, &user, query, dbProvider, oauthID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.UserDBToEntity(&user), nil
+}
+
+// LinkOAuth привязывает OAuth-аккаунт к существующему пользователю без привязки.
+// Аватар перезаписывается только если у пользователя его ещё нет.
+// Если пользователь уже привязан к какому-либо провайдеру, возвращается sql.ErrNoRows.
+func (repo *UserDBRepo) LinkOAuth(
+	ctx context.Context,
+	id uuid.UUID,
+	provider entitymodel.OAuthProvider,
+	oauthID string,
+	avatarURL *string,
+) error {
+	query := `
+	update users
+	set oauth_provider = $2,
+	    oauth_id = $3,
+	    avatar_url = coalesce(avatar_url, $4),
+	    is_verified = true,
+	    updated_at = now()
+	where id = $1
+	  and oauth_provider is null
+	`
+
+	dbProvider := converter.OAuthProviderEntityToDB(provider)
+
+	return repo.execAffectingUser(ctx, "failed to link oauth account", query, id, dbProvider, oauthID, avatarURL)
+}
+
+func (repo *UserDBRepo) MarkVerified(ctx context.Context, id uuid.UUID) error {
+	query := `
+	update users
+	set is_verified = true,
+	    updated_at = now()
+	where id = $1
+	`
+
+	return repo.execAffectingUser(ctx, "failed to mark user verified", query, id)
+}
+
+func (repo *UserDBRepo) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+	query := `
+	update users
+	set hashed_password = $2,
+	    updated_at = now()
+	where id = $1
+	`
+
+	return repo.execAffectingUser(ctx, "failed to update password", query, id, hashedPassword)
+}
+
+func (repo *UserDBRepo) RehashPassword(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	query := `
+	update users
+	set hashed_password = $3
+	where id = $1 and hashed_password = $2
+	`
+
+	return repo.execAffectingUser(ctx, "failed to rehash password", query, id, oldHash, newHash)
+}
+
+func (repo *UserDBRepo) UpdateProfile(ctx context.Context, id uuid.UUID, name string, avatarURL *string) error {
+	query := `
+	update users
+	set name = $2,
+	    avatar_url = $3,
+	    updated_at = now()
+	where id = $1
+	`
+
+	return repo.execAffectingUser(ctx, "failed to update profile", query, id, name, avatarURL)
+}
+
+// UpgradeGuest превращает гостя в обычного пользователя. id не меняется,
+// поэтому все голоса и реакции гостя остаются за ним.
+func (repo *UserDBRepo) UpgradeGuest(ctx context.Context, id uuid.UUID, name, email, hashedPassword string) error {
+	query := `
+	update users
+	set name = $2,
+	    email = $3,
+	    hashed_password = $4,
+	    is_guest = false,
+	    role = 'user',
+	    is_verified = false,
+	    updated_at = now()
+	where id = $1 and is_guest
+	`
+
+	return repo.execAffectingUser(ctx, "failed to upgrade guest", query, id, name, email, hashedPassword)
+}
+
+// SetRole меняет глобальную роль пользователя
+func (repo *UserDBRepo) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	query := `
+	update users
+	set role = $2,
+	    updated_at = now()
+	where id = $1
+	`
+
+	return repo.execAffectingUser(ctx, "failed to set user role", query, id, role)
+}
+
+// RevokeTokens отзывает все выданные пользователю JWT
+func (repo *UserDBRepo) RevokeTokens(ctx context.Context, id uuid.UUID) error {
+	query := `
+	update users
+	set tokens_valid_after = now(),
+	    updated_at = now()
+	where id = $1
+	`
+
+	return repo.execAffectingUser(ctx, "failed to revoke tokens", query, id)
+}
+
+// Anonymize удаляет персональные данные пользователя, сохраняя строку,
+// чтобы голоса и реакции в прошлых сессиях продолжали ссылаться на неё
+func (repo *UserDBRepo) Anonymize(ctx context.Context, id uuid.UUID, placeholderName string) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	update users
+	set name = $2,
+	    email = null,
+	    hashed_password = null,
+	    oauth_provider = null,
+	    oauth_id = null,
+	    avatar_url = null,
+	    socket_id = null,
+	    is_active = false,
+	    is_verified = false,
+	    mfa_secret = null,
+	    mfa_enabled = false,
+	    mfa_last_step = null,
+	    tokens_valid_after = now(),
+	    deleted_at = now(),
+	    updated_at = now()
+	where id = $1 and deleted_at is null
+	`, id, placeholderName)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	// Имя создателя денормализовано в sessions
+	_, err = tx.ExecContext(ctx, `update sessions set creator_name = $2 where creator_id = $1`, id, placeholderName)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user sessions: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from password_reset_tokens where user_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete password reset tokens: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from mfa_recovery_codes where user_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete mfa recovery codes: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from api_tokens where user_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete api tokens: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from session_templates where owner_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete session templates: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SetMFASecret сохраняет секрет TOTP на время подключения; 2FA включается только после EnableMFA
+func (repo *UserDBRepo) SetMFASecret(ctx context.Context, id uuid.UUID, secret string) error {
+	query := `
+	update users
+	set mfa_secret = $2,
+	    mfa_last_step = null,
+	    updated_at = now()
+	where id = $1 and not mfa_enabled
+	`
+
+	return repo.execAffectingUser(ctx, "failed to set mfa secret", query, id, secret)
+}
+
+func (repo *UserDBRepo) EnableMFA(ctx context.Context, id uuid.UUID) error {
+	query := `
+	update users
+	set mfa_enabled = true,
+	    updated_at = now()
+	where id = $1 and mfa_secret is not null
+	`
+
+	return repo.execAffectingUser(ctx, "failed to enable mfa", query, id)
+}
+
+// DisableMFA отключает 2FA и удаляет секрет вместе с кодами восстановления
+func (repo *UserDBRepo) DisableMFA(ctx context.Context, id uuid.UUID) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	update users
+	set mfa_secret = null,
+	    mfa_enabled = false,
+	    mfa_last_step = null,
+	    updated_at = now()
+	where id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to disable mfa: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from mfa_recovery_codes where user_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete mfa recovery codes: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UseMFAStep запоминает принятый интервал TOTP. Если интервал не новее уже принятого
+// (повтор того же кода), возвращается sql.ErrNoRows.
+func (repo *UserDBRepo) UseMFAStep(ctx context.Context, id uuid.UUID, step int64) error {
+	query := `
+	update users
+	set mfa_last_step = $2
+	where id = $1 and (mfa_last_step is null or mfa_last_step < $2)
+	`
+
+	return repo.execAffectingUser(ctx, "failed to save mfa step", query, id, step)
+}
+
+// execAffectingUser выполняет update по id и возвращает sql.ErrNoRows, если пользователь не найден
+func (repo *UserDBRepo) execAffectingUser(ctx context.Context, errMsg, query string, args ...interface{}) error {
+	result, err := repo.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
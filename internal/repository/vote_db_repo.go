@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/dbmodel"
+	"backend_go/internal/model/entitymodel"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type VoteDBRepo struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewVoteDBRepo(db *sqlx.DB, logger *zap.Logger) *VoteDBRepo {
+	return &VoteDBRepo{
+		db:  db,
+		log: logger,
+	}
+}
+
+func (r *VoteDBRepo) GetByUser(ctx context.Context, userID string) ([]*entitymodel.Vote, error) {
+	query := `
+	select id, session_id, user_id, value, created_at, updated_at
+		from votes
+		where user_id = $1
+		order by created_at
+	`
+
+	var votes []dbmodel.Vote
+	if err := r.db.SelectContext(ctx, &votes, query, userID); err != nil {
+		r.log.Debug("error message", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]*entitymodel.Vote, 0, len(votes))
+	for i := range votes {
+		result = append(result, converter.VoteDBToEntity(&votes[i]))
+	}
+
+	return result, nil
+}
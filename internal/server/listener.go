@@ -0,0 +1,131 @@
+package server
+
+import (
+	"expvar"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rejectTimeout - сколько времени даём на отправку 503 клиенту сверх лимита
+const rejectTimeout = time.Second
+
+// maxConcurrentRejects - сколько отказов 503 отправляется одновременно. При наплыве
+// соединений остальные закрываются сразу, чтобы отказы не порождали неограниченно горутин.
+const maxConcurrentRejects = 64
+
+const rejectBody = `{"error":{"code":"server_overloaded","message":"too many concurrent connections"}}`
+
+var rejectResponse = "HTTP/1.1 503 Service Unavailable\r\n" +
+	"Content-Type: application/json; charset=utf-8\r\n" +
+	"Retry-After: 1\r\n" +
+	"Connection: close\r\n" +
+	"Content-Length: " + strconv.Itoa(len(rejectBody)) + "\r\n" +
+	"\r\n" +
+	rejectBody
+
+// Метрики соединений, доступны администраторам на /debug/vars
+var connectionMetrics = expvar.NewMap("http_connections")
+
+// limitedListener ограничивает число одновременно открытых соединений.
+// Соединения сверх лимита принимаются и сразу получают 503, а не висят в очереди ядра.
+// Слот освобождается при закрытии соединения, поэтому соединения, переведённые
+// на другой протокол через Hijack (WebSocket), тоже учитываются в лимите.
+type limitedListener struct {
+	net.Listener
+	slots     chan struct{}
+	rejecting chan struct{}
+	log       *zap.Logger
+}
+
+func newLimitedListener(listener net.Listener, maxConnections int, log *zap.Logger) net.Listener {
+	connectionMetrics.Set("limit", expvarInt(int64(maxConnections)))
+
+	return &limitedListener{
+		Listener:  listener,
+		slots:     make(chan struct{}, maxConnections),
+		rejecting: make(chan struct{}, maxConcurrentRejects),
+		log:       log,
+	}
+}
+
+func (l *limitedListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case l.slots <- struct{}{}:
+			connectionMetrics.Add("accepted", 1)
+			connectionMetrics.Add("active", 1)
+			return &limitedConn{Conn: conn, release: l.release}, nil
+		default:
+			connectionMetrics.Add("rejected", 1)
+			l.log.Warn("connection rejected: max connections reached",
+				zap.String("remote_addr", conn.RemoteAddr().String()),
+				zap.Int("max_connections", cap(l.slots)),
+			)
+			l.reject(conn)
+		}
+	}
+}
+
+// reject отправляет 503 в отдельной горутине, пока их не больше maxConcurrentRejects,
+// иначе закрывает соединение без ответа
+func (l *limitedListener) reject(conn net.Conn) {
+	select {
+	case l.rejecting <- struct{}{}:
+		go func() {
+			defer func() { <-l.rejecting }()
+			reject(conn)
+		}()
+	default:
+		connectionMetrics.Add("dropped", 1)
+		_ = conn.Close()
+	}
+}
+
+func (l *limitedListener) release() {
+	<-l.slots
+	connectionMetrics.Add("active", -1)
+}
+
+// reject отвечает 503 и закрывает соединение. Перед закрытием дочитываем запрос,
+// иначе непрочитанные данные приведут к RST и клиент может не увидеть ответ.
+func reject(conn net.Conn) {
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(rejectTimeout))
+	if _, err := io.WriteString(conn, rejectResponse); err != nil {
+		return
+	}
+
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		_ = tcpConn.CloseWrite()
+	}
+	_, _ = io.Copy(io.Discard, conn)
+}
+
+type limitedConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
+
+func expvarInt(value int64) *expvar.Int {
+	v := new(expvar.Int)
+	v.Set(value)
+	return v
+}
@@ -3,47 +3,222 @@ package server
 import (
 	"backend_go/internal/api/handler"
 	"backend_go/internal/api/middleware"
+	"backend_go/internal/api/validation"
+	"backend_go/internal/infrastructure/cache"
 	"backend_go/internal/infrastructure/config"
 	"backend_go/internal/infrastructure/db"
+	"backend_go/internal/infrastructure/mailer"
+	"backend_go/internal/infrastructure/oauth"
+	"backend_go/internal/infrastructure/ratelimit"
+	"backend_go/internal/model/entitymodel"
 	"backend_go/internal/repository"
 	"backend_go/internal/service"
+	"backend_go/pkg/hash"
+	"backend_go/pkg/password"
 	"context"
+	"expvar"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"net"
 	"net/http"
 	"time"
 )
 
+const oidcDiscoveryTimeout = 10 * time.Second
+
 type Server struct {
-	httpServer *http.Server
-	log        *zap.Logger
+	httpServer     *http.Server
+	maxConnections int
+	log            *zap.Logger
 }
 
 func NewServer(cfg *config.Config, log *zap.Logger) (*Server, error) {
 
 	setupGin(cfg)
 
+	if err := validation.Register(); err != nil {
+		return nil, err
+	}
+
 	// Инициализация БД
 	dbconn, err := db.NewPostgresDB(cfg.DatabaseURL, cfg.DBMaxOpenConns, cfg.DBMaxIdleConns, cfg.DBConnMaxLifetime)
 	if err != nil {
 		return nil, err
 	}
 
+	redisClient, err := setupRedis(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Инициализация репозиториев
 	userDBRepo := repository.NewUserDBRepo(dbconn.DB, log)
 	sessionDBRepo := repository.NewSessionDBRepo(dbconn.DB, log)
+	oauthStateRepo := repository.NewOAuthStateMemoryRepo(log)
+	passwordResetRepo := repository.NewPasswordResetDBRepo(dbconn.DB, log)
+	loginAttemptRepo := setupLoginAttemptRepo(redisClient, log)
+	mfaRecoveryCodeRepo := repository.NewMFARecoveryCodeDBRepo(dbconn.DB, log)
+	apiTokenRepo := repository.NewAPITokenDBRepo(dbconn.DB, log)
+	sessionParticipantRepo := repository.NewSessionParticipantDBRepo(dbconn.DB, log)
+	sessionEventRepo := repository.NewSessionEventDBRepo(dbconn.DB, log)
+	sessionBanRepo := repository.NewSessionBanDBRepo(dbconn.DB, log)
+	sessionInviteRepo := repository.NewSessionInviteDBRepo(dbconn.DB, log)
+	sessionStoryRepo := repository.NewSessionStoryDBRepo(dbconn.DB, log)
+	sessionTemplateRepo := repository.NewSessionTemplateDBRepo(dbconn.DB, log)
+	voteDBRepo := repository.NewVoteDBRepo(dbconn.DB, log)
+	reactionDBRepo := repository.NewReactionDBRepo(dbconn.DB, log)
 
 	// Инициализация сервисов
+	mailSender := setupMailer(cfg)
 	jwtService := service.NewJwtService(cfg, log)
-	authService := service.NewAuthService(userDBRepo, jwtService, log)
-	sessionService := service.NewSessionService(sessionDBRepo, log)
+	passwordHasher, err := setupPasswordHasher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	passwordService, err := setupPasswordService(cfg, passwordHasher, log)
+	if err != nil {
+		return nil, err
+	}
+	verificationService := service.NewVerificationService(
+		userDBRepo,
+		jwtService,
+		mailSender,
+		cfg.AppBaseURL,
+		cfg.GetEmailVerificationTTL(),
+		log,
+	)
+	loginProtectionService := service.NewLoginProtectionService(
+		loginAttemptRepo,
+		service.LoginProtectionPolicy{
+			DelayAfter:      cfg.LoginProtection.DelayAfter,
+			LockoutAfter:    cfg.LoginProtection.LockoutAfter,
+			IPLockoutAfter:  cfg.LoginProtection.IPLockoutAfter,
+			BaseDelay:       time.Second,
+			MaxDelay:        cfg.LoginProtection.GetMaxDelay(),
+			LockoutDuration: cfg.LoginProtection.GetLockoutDuration(),
+			Window:          cfg.LoginProtection.GetWindow(),
+		},
+		log,
+	)
+	passcodeProtectionService := service.NewPasscodeProtectionService(
+		loginAttemptRepo,
+		service.PasscodeProtectionPolicy{
+			LockoutAfter:    cfg.LoginProtection.PasscodeLockoutAfter,
+			IPLockoutAfter:  cfg.LoginProtection.PasscodeIPLockoutAfter,
+			LockoutDuration: cfg.LoginProtection.GetLockoutDuration(),
+			Window:          cfg.LoginProtection.GetWindow(),
+		},
+		log,
+	)
+	mfaService := service.NewMFAService(
+		userDBRepo,
+		mfaRecoveryCodeRepo,
+		jwtService,
+		loginProtectionService,
+		cfg.MFAIssuer,
+		cfg.GetMFAChallengeTTL(),
+		log,
+	)
+	authService := service.NewAuthService(
+		userDBRepo,
+		jwtService,
+		verificationService,
+		passwordService,
+		loginProtectionService,
+		mfaService,
+		log,
+	)
+	sessionService := service.NewSessionService(
+		sessionDBRepo,
+		sessionParticipantRepo,
+		sessionEventRepo,
+		sessionBanRepo,
+		sessionStoryRepo,
+		sessionTemplateRepo,
+		passwordHasher,
+		passcodeProtectionService,
+		cfg.RequireVerifiedEmail,
+		log,
+	)
+	inviteService := service.NewInviteService(
+		sessionInviteRepo,
+		sessionService,
+		authService,
+		cfg.JWTSecret,
+		cfg.AppBaseURL,
+		log,
+	)
+	storyService := service.NewStoryService(sessionDBRepo, sessionStoryRepo, log)
+	templateService := service.NewTemplateService(sessionTemplateRepo, log)
+	adminService := service.NewAdminService(userDBRepo, log)
+	passwordResetService := service.NewPasswordResetService(
+		userDBRepo,
+		passwordResetRepo,
+		passwordService,
+		mailSender,
+		cfg.AppBaseURL,
+		cfg.GetPasswordResetTTL(),
+		log,
+	)
+
+	apiTokenService := service.NewAPITokenService(apiTokenRepo, userDBRepo, log)
+
+	accountService := service.NewAccountService(userDBRepo, sessionDBRepo, voteDBRepo, reactionDBRepo, log)
+
+	oauthProviders, err := setupOAuthProviders(cfg)
+	if err != nil {
+		return nil, err
+	}
+	oauthService := service.NewOAuthService(
+		oauthProviders,
+		oauthStateRepo,
+		userDBRepo,
+		jwtService,
+		mfaService,
+		cfg.GetOAuthStateTTL(),
+		log,
+	)
 
 	// Инициализация хендлеров
-	authHandler := handler.NewAuthHandler(authService, log)
+	authHandler := handler.NewAuthHandler(authService, verificationService, log)
 	sessionHandler := handler.NewSessionHandler(sessionService, log)
+	oauthHandler := handler.NewOAuthHandler(oauthService, log)
+	passwordHandler := handler.NewPasswordHandler(passwordResetService, log)
+	accountHandler := handler.NewAccountHandler(accountService, log)
+	mfaHandler := handler.NewMFAHandler(mfaService, log)
+	apiTokenHandler := handler.NewAPITokenHandler(apiTokenService, log)
+	adminHandler := handler.NewAdminHandler(adminService, log)
+	inviteHandler := handler.NewInviteHandler(inviteService, log)
+	storyHandler := handler.NewStoryHandler(storyService, log)
+	templateHandler := handler.NewTemplateHandler(templateService, log)
 
 	// Настройка роутинга
-	router := setupRouter(authHandler, sessionHandler, authService)
+	router := setupRouter(
+		authHandler,
+		sessionHandler,
+		oauthHandler,
+		passwordHandler,
+		accountHandler,
+		mfaHandler,
+		apiTokenHandler,
+		adminHandler,
+		inviteHandler,
+		storyHandler,
+		templateHandler,
+		authService,
+		apiTokenService,
+		sessionService,
+		setupRateLimiter(redisClient),
+		cfg.RateLimit,
+		log,
+	)
+	// ClientIP используется для блокировки входа и лимитов по IP, поэтому заголовкам
+	// X-Forwarded-For верим только от настроенных прокси
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
 
 	httpServer := &http.Server{
 		Addr:         cfg.ServerAddr,
@@ -53,13 +228,24 @@ func NewServer(cfg *config.Config, log *zap.Logger) (*Server, error) {
 	}
 
 	return &Server{
-		httpServer: httpServer,
-		log:        log,
+		httpServer:     httpServer,
+		maxConnections: cfg.MaxConnections,
+		log:            log,
 	}, nil
 }
 
+// Run принимает соединения через limitedListener: сверх MaxConnections клиенты получают 503
 func (s *Server) Run() error {
-	return s.httpServer.ListenAndServe()
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+
+	if s.maxConnections > 0 {
+		listener = newLimitedListener(listener, s.maxConnections, s.log)
+	}
+
+	return s.httpServer.Serve(listener)
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
@@ -76,32 +262,296 @@ func setupGin(cfg *config.Config) {
 	}
 }
 
+func setupMailer(cfg *config.Config) mailer.Mailer {
+	if cfg.MailDriver == "smtp" {
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	}
+
+	return mailer.NewMemoryMailer()
+}
+
+// setupRedis подключается к Redis, если задан REDIS_URL. nil означает работу без Redis.
+func setupRedis(cfg *config.Config) (*redis.Client, error) {
+	if cfg.RedisURL == "" {
+		return nil, nil
+	}
+	return cache.NewRedisClient(cfg.RedisURL)
+}
+
+// setupLoginAttemptRepo хранит попытки входа в Redis, если он настроен, иначе в памяти процесса
+func setupLoginAttemptRepo(redisClient *redis.Client, log *zap.Logger) repository.LoginAttemptRepository {
+	if redisClient == nil {
+		return repository.NewLoginAttemptMemoryRepo(log)
+	}
+	return repository.NewLoginAttemptRedisRepo(redisClient, log)
+}
+
+// setupRateLimiter хранит вёдра лимитов в Redis, если он настроен, иначе в памяти процесса
+func setupRateLimiter(redisClient *redis.Client) ratelimit.Limiter {
+	if redisClient == nil {
+		return ratelimit.NewMemoryLimiter()
+	}
+	return ratelimit.NewRedisLimiter(redisClient)
+}
+
+// setupPasswordService собирает политику паролей из конфига и загружает список утёкших паролей, если он задан
+func setupPasswordService(cfg *config.Config, hasher *hash.PasswordHasher, log *zap.Logger) (service.PasswordService, error) {
+	policy := password.Policy{
+		MinLength:     cfg.PasswordPolicy.MinLength,
+		MaxLength:     cfg.PasswordPolicy.MaxLength,
+		RequireUpper:  cfg.PasswordPolicy.RequireUpper,
+		RequireLower:  cfg.PasswordPolicy.RequireLower,
+		RequireDigit:  cfg.PasswordPolicy.RequireDigit,
+		RequireSymbol: cfg.PasswordPolicy.RequireSymbol,
+	}
+
+	var breached *password.BreachedList
+	if cfg.PasswordPolicy.BreachedListPath != "" {
+		var err error
+		breached, err = password.LoadBreachedList(cfg.PasswordPolicy.BreachedListPath)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("breached password list loaded", zap.Int("hashes", breached.Size()))
+	}
+
+	return service.NewPasswordService(policy, breached, hasher, log), nil
+}
+
+// setupPasswordHasher выбирает алгоритм для новых хешей (паролей и кодов-паролей сессий); хеши второго алгоритма по-прежнему проверяются
+// и пересчитываются основным при следующем входе пользователя
+func setupPasswordHasher(cfg *config.Config) (*hash.PasswordHasher, error) {
+	bcryptHasher, err := hash.NewBcryptHasher(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	argon2Hasher, err := hash.NewArgon2idHasher(hash.Argon2Params{
+		Memory:      uint32(cfg.Argon2Memory),
+		Iterations:  uint32(cfg.Argon2Iterations),
+		Parallelism: uint8(cfg.Argon2Parallelism),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.PasswordHashAlgo {
+	case "argon2id":
+		return hash.NewPasswordHasher(argon2Hasher, bcryptHasher), nil
+	case "bcrypt":
+		return hash.NewPasswordHasher(bcryptHasher, argon2Hasher), nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", cfg.PasswordHashAlgo)
+	}
+}
+
+// setupOAuthProviders регистрирует Google и Яндекс (если задан client id) и все OIDC-провайдеры из конфига.
+// Для OIDC при старте выполняется discovery, поэтому недоступный провайдер не даст запустить сервер.
+// Повторяющиеся имена провайдеров (в том числе OIDC_PROVIDERS=google) также останавливают запуск.
+func setupOAuthProviders(cfg *config.Config) (*oauth.Registry, error) {
+	registry := oauth.NewRegistry()
+
+	if cfg.GoogleOAuth.Enabled() {
+		if err := registry.Register(oauth.NewGoogleProvider(cfg.GoogleOAuth)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.YandexOAuth.Enabled() {
+		if err := registry.Register(oauth.NewYandexProvider(cfg.YandexOAuth)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, oidcCfg := range cfg.OIDCProviders {
+		if _, err := entitymodel.ParseOAuthProvider(oidcCfg.Name); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), oidcDiscoveryTimeout)
+		provider, err := oauth.NewOIDCProvider(ctx, oidcCfg)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(provider); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
 func setupRouter(
 	authHandler *handler.AuthHandler,
 	sessionHandler *handler.SessionHandler,
+	oauthHandler *handler.OAuthHandler,
+	passwordHandler *handler.PasswordHandler,
+	accountHandler *handler.AccountHandler,
+	mfaHandler *handler.MFAHandler,
+	apiTokenHandler *handler.APITokenHandler,
+	adminHandler *handler.AdminHandler,
+	inviteHandler *handler.InviteHandler,
+	storyHandler *handler.StoryHandler,
+	templateHandler *handler.TemplateHandler,
 	authService service.AuthService,
+	apiTokenService service.APITokenService,
+	sessionService service.SessionService,
+	limiter ratelimit.Limiter,
+	limits config.RateLimitConfig,
+	log *zap.Logger,
 ) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler(log))
+	router.Use(middleware.RateLimit(limiter, "global", ratelimit.PerMinute(limits.Global), log))
+	router.NoRoute(middleware.NoRoute())
+
+	authRateLimit := middleware.RateLimit(limiter, "auth", ratelimit.PerMinute(limits.Auth), log)
+	sessionsRateLimit := middleware.RateLimit(limiter, "sessions", ratelimit.PerMinute(limits.Sessions), log)
+	authMiddleware := middleware.AuthMiddleware(authService, apiTokenService)
+
+	// expvar показывает cmdline процесса (там могут быть секреты) и memstats - только для администраторов
+	router.GET("/debug/vars",
+		authMiddleware,
+		middleware.DenyAPITokens(),
+		middleware.RequireRole(entitymodel.RoleAdmin),
+		gin.WrapH(expvar.Handler()),
+	)
+	// Права в сессии: ведущий, соведущие (управляют ходом), голосующие и наблюдатели
+	sessionRole := func(roles ...string) gin.HandlerFunc {
+		return middleware.RequireSessionRole(sessionService, roles...)
+	}
+	anyParticipant := sessionRole(
+		entitymodel.SessionRoleFacilitator,
+		entitymodel.SessionRoleCoFacilitator,
+		entitymodel.SessionRoleVoter,
+		entitymodel.SessionRoleObserver,
+	)
+	sessionManager := sessionRole(entitymodel.SessionRoleFacilitator, entitymodel.SessionRoleCoFacilitator)
+	sessionFacilitator := sessionRole(entitymodel.SessionRoleFacilitator)
+	readSessions := middleware.RequireScope(entitymodel.ScopeSessionsRead)
+	writeSessions := middleware.RequireScope(entitymodel.ScopeSessionsWrite)
 
 	apiGroup := router.Group("/api")
 	{
 		authGroup := apiGroup.Group("/auth")
+		authGroup.Use(authRateLimit)
 		{
 			authGroup.POST("/register", authHandler.Register)
 			authGroup.POST("/login", authHandler.Login)
 			authGroup.POST("/guest_login", authHandler.GuestLogin)
+			authGroup.GET("/oauth/:provider/start", oauthHandler.Start)
+			authGroup.GET("/oauth/:provider/callback", oauthHandler.Callback)
+			authGroup.POST("/verify", authHandler.VerifyEmail)
+			authGroup.POST("/password/forgot", passwordHandler.Forgot)
+			authGroup.POST("/password/reset", passwordHandler.Reset)
+			authGroup.POST("/mfa/verify", mfaHandler.Verify)
 		}
 
 		authProtectedGroup := apiGroup.Group("/auth")
-		authProtectedGroup.Use(middleware.AuthMiddleware(authService))
+		authProtectedGroup.Use(authMiddleware, authRateLimit)
 		{
-			authProtectedGroup.GET("/me", authHandler.Me)
+			authProtectedGroup.GET("/me", middleware.RequireScope(entitymodel.ScopeProfileRead), authHandler.Me)
+		}
+
+		// Управление аккаунтом недоступно персональным токенам
+		accountGroup := apiGroup.Group("/auth")
+		accountGroup.Use(authMiddleware, middleware.DenyAPITokens(), authRateLimit)
+		{
+			accountGroup.PATCH("/me", authHandler.UpdateMe)
+			accountGroup.POST("/me/password", authHandler.ChangePassword)
+			accountGroup.DELETE("/me", accountHandler.DeleteMe)
+			accountGroup.GET("/me/export", accountHandler.ExportMe)
+			accountGroup.POST("/guest/upgrade", authHandler.UpgradeGuest)
+			accountGroup.POST("/verify/resend", authHandler.ResendVerification)
+			accountGroup.POST("/mfa/enroll", mfaHandler.Enroll)
+			accountGroup.POST("/mfa/confirm", mfaHandler.Confirm)
+			accountGroup.POST("/mfa/disable", mfaHandler.Disable)
+			accountGroup.GET("/tokens", apiTokenHandler.List)
+			accountGroup.POST("/tokens", apiTokenHandler.Create)
+			accountGroup.DELETE("/tokens/:id", apiTokenHandler.Revoke)
 		}
 
 		sessionGroup := apiGroup.Group("/sessions")
-		sessionGroup.Use(middleware.AuthMiddleware(authService))
+		sessionGroup.Use(authMiddleware, sessionsRateLimit)
+		{
+			sessionGroup.GET("",
+				readSessions,
+				middleware.RequireRole(entitymodel.RoleUser, entitymodel.RoleAdmin),
+				sessionHandler.GetUserSession,
+			)
+			sessionGroup.POST("", writeSessions, sessionHandler.CreateSession)
+			sessionGroup.POST("/join", writeSessions, sessionHandler.JoinByCode)
+			sessionGroup.GET("/:id", readSessions, anyParticipant, sessionHandler.GetSession)
+			sessionGroup.GET("/:id/events", readSessions, anyParticipant, sessionHandler.ListEvents)
+			sessionGroup.POST("/:id/join", writeSessions, sessionHandler.Join)
+			sessionGroup.POST("/:id/join_code", writeSessions, sessionManager, sessionHandler.RegenerateJoinCode)
+			sessionGroup.PUT("/:id/access", writeSessions, sessionFacilitator, sessionHandler.UpdateAccess)
+			sessionGroup.GET("/:id/invites", readSessions, sessionManager, inviteHandler.List)
+			sessionGroup.POST("/:id/invites", writeSessions, sessionManager, inviteHandler.Create)
+			sessionGroup.DELETE("/:id/invites/:invite_id", writeSessions, sessionManager, inviteHandler.Revoke)
+			sessionGroup.POST("/:id/archive", writeSessions, sessionFacilitator, sessionHandler.Archive)
+			sessionGroup.DELETE("/:id/archive", writeSessions, sessionFacilitator, sessionHandler.Unarchive)
+			sessionGroup.POST("/:id/clone", writeSessions, sessionManager, sessionHandler.CloneSession)
+			sessionGroup.GET("/:id/stories", readSessions, anyParticipant, storyHandler.List)
+			sessionGroup.POST("/:id/stories", writeSessions, sessionManager, storyHandler.Create)
+			sessionGroup.PUT("/:id/stories/:story_id/estimate", writeSessions, sessionManager, storyHandler.SetEstimate)
+			sessionGroup.GET("/:id/participants", readSessions, anyParticipant, sessionHandler.ListParticipants)
+			sessionGroup.PUT("/:id/participants/:user_id/role",
+				writeSessions,
+				sessionManager,
+				sessionHandler.SetParticipantRole,
+			)
+			sessionGroup.DELETE("/:id/participants/:user_id", writeSessions, sessionManager, sessionHandler.Kick)
+			sessionGroup.GET("/:id/bans", readSessions, sessionManager, sessionHandler.ListBans)
+			sessionGroup.POST("/:id/bans", writeSessions, sessionManager, sessionHandler.Ban)
+			sessionGroup.DELETE("/:id/bans/:user_id", writeSessions, sessionManager, sessionHandler.Unban)
+			sessionGroup.POST("/:id/facilitator", writeSessions, sessionFacilitator, sessionHandler.TransferFacilitator)
+			sessionGroup.POST("/:id/co_facilitators", writeSessions, sessionFacilitator, sessionHandler.AddCoFacilitator)
+			sessionGroup.DELETE("/:id/co_facilitators/:user_id",
+				writeSessions,
+				sessionFacilitator,
+				sessionHandler.RemoveCoFacilitator,
+			)
+			sessionGroup.POST("/:id/reveal", writeSessions, sessionManager, sessionHandler.RevealCards)
+			sessionGroup.POST("/:id/reset", writeSessions, sessionManager, sessionHandler.ResetVotes)
+		}
+
+		// Шаблоны сессий есть только у зарегистрированных пользователей
+		templateGroup := apiGroup.Group("/templates")
+		templateGroup.Use(
+			authMiddleware,
+			sessionsRateLimit,
+			middleware.RequireRole(entitymodel.RoleUser, entitymodel.RoleAdmin),
+		)
+		{
+			templateGroup.GET("", readSessions, templateHandler.List)
+			templateGroup.POST("", writeSessions, templateHandler.Create)
+			templateGroup.PUT("/:id", writeSessions, templateHandler.Update)
+			templateGroup.DELETE("/:id", writeSessions, templateHandler.Delete)
+		}
+
+		// Вход по приглашению доступен и без авторизации: гость создаётся в том же запросе
+		inviteGroup := apiGroup.Group("/invites")
+		inviteGroup.Use(authRateLimit)
+		{
+			inviteGroup.GET("/:token", inviteHandler.Preview)
+			inviteGroup.POST("/:token/accept",
+				middleware.OptionalAuthMiddleware(authService, apiTokenService),
+				writeSessions,
+				inviteHandler.Accept,
+			)
+		}
+
+		adminGroup := apiGroup.Group("/admin")
+		adminGroup.Use(authMiddleware, middleware.DenyAPITokens(), middleware.RequireRole(entitymodel.RoleAdmin))
 		{
-			sessionGroup.GET("", sessionHandler.GetUserSession)
+			adminGroup.PUT("/users/:id/role", adminHandler.SetUserRole)
 		}
 	}
 
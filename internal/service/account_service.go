@@ -0,0 +1,97 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+type accountService struct {
+	userRepo     repository.UserRepository
+	sessionRepo  repository.SessionRepository
+	voteRepo     repository.VoteRepository
+	reactionRepo repository.ReactionRepository
+	log          *zap.Logger
+}
+
+func NewAccountService(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	voteRepo repository.VoteRepository,
+	reactionRepo repository.ReactionRepository,
+	log *zap.Logger,
+) *accountService {
+	return &accountService{
+		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
+		voteRepo:     voteRepo,
+		reactionRepo: reactionRepo,
+		log:          log,
+	}
+}
+
+// DeleteAccount анонимизирует пользователя. Голоса и реакции остаются привязаны к его id
+// и в прошлых сессиях отображаются от имени entitymodel.DeletedUserName.
+func (s *accountService) DeleteAccount(ctx context.Context, user *entitymodel.User) error {
+	if err := s.userRepo.Anonymize(ctx, user.ID, entitymodel.DeletedUserName); err != nil {
+		return err
+	}
+
+	s.log.Info("user account deleted", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// ExportData собирает всё, что хранится о пользователе: профиль, созданные сессии, голоса и реакции
+func (s *accountService) ExportData(ctx context.Context, user *entitymodel.User) (*apimodel.UserDataExport, error) {
+	userID := user.ID.String()
+
+	sessions, err := s.sessionRepo.GetByCreator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := s.voteRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reactionsSent, err := s.reactionRepo.GetByFromUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reactionsReceived, err := s.reactionRepo.GetByToUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &apimodel.UserDataExport{
+		ExportedAt:        time.Now().UTC(),
+		Profile:           converter.ToUserProfile(user),
+		CreatedAt:         user.CreatedAt,
+		SessionsCreated:   make([]*apimodel.Session, 0, len(sessions)),
+		Votes:             make([]*apimodel.Vote, 0, len(votes)),
+		ReactionsSent:     make([]*apimodel.Reaction, 0, len(reactionsSent)),
+		ReactionsReceived: make([]*apimodel.Reaction, 0, len(reactionsReceived)),
+	}
+
+	for _, session := range sessions {
+		export.SessionsCreated = append(export.SessionsCreated, converter.SessionEntityToAPI(session))
+	}
+	for _, vote := range votes {
+		export.Votes = append(export.Votes, converter.VoteEntityToAPI(vote))
+	}
+	for _, reaction := range reactionsSent {
+		export.ReactionsSent = append(export.ReactionsSent, converter.ReactionEntityToAPI(reaction))
+	}
+	for _, reaction := range reactionsReceived {
+		export.ReactionsReceived = append(export.ReactionsReceived, converter.ReactionEntityToAPI(reaction))
+	}
+
+	s.log.Info("user data exported", zap.String("user_id", userID))
+	return export, nil
+}
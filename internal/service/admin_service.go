@@ -0,0 +1,66 @@
+package service
+
+import (
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type adminService struct {
+	userRepo repository.UserRepository
+	log      *zap.Logger
+}
+
+func NewAdminService(userRepo repository.UserRepository, log *zap.Logger) *adminService {
+	return &adminService{
+		userRepo: userRepo,
+		log:      log,
+	}
+}
+
+// SetUserRole назначает глобальную роль. Гостю нельзя выдать роль зарегистрированного
+// пользователя (и наоборот), а администратор не может снять роль с самого себя.
+func (s *adminService) SetUserRole(
+	ctx context.Context,
+	actor *entitymodel.User,
+	userID uuid.UUID,
+	role string,
+) (*entitymodel.User, error) {
+	if actor.ID == userID {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, ErrUserNotFound
+	}
+
+	if (role == entitymodel.RoleGuest) != user.IsGuest {
+		return nil, ErrRoleNotAllowed
+	}
+
+	if err := s.userRepo.SetRole(ctx, user.ID, role); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("user role changed",
+		zap.String("actor_id", actor.ID.String()),
+		zap.String("user_id", user.ID.String()),
+		zap.String("old_role", user.Role),
+		zap.String("new_role", role),
+	)
+
+	user.Role = role
+	return user, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"backend_go/pkg/hash"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	apiTokenSize = 32
+	// apiTokenPrefixLength - сколько первых символов токена показываем в списке
+	apiTokenPrefixLength  = len(entitymodel.APITokenPrefix) + 6
+	maxAPITokensPerUser   = 20
+	apiTokenTouchInterval = time.Minute
+)
+
+type apiTokenService struct {
+	tokenRepo repository.APITokenRepository
+	userRepo  repository.UserRepository
+	log       *zap.Logger
+}
+
+func NewAPITokenService(
+	tokenRepo repository.APITokenRepository,
+	userRepo repository.UserRepository,
+	log *zap.Logger,
+) *apiTokenService {
+	return &apiTokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		log:       log,
+	}
+}
+
+// Create выпускает новый токен. Значение токена возвращается только здесь.
+func (s *apiTokenService) Create(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.APITokenCreate,
+) (*apimodel.APITokenCreated, error) {
+	if user.IsGuest {
+		return nil, ErrGuestForbidden
+	}
+
+	count, err := s.tokenRepo.CountByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAPITokensPerUser {
+		return nil, ErrTooManyAPITokens
+	}
+
+	random, err := hash.GenerateToken(apiTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api token: %w", err)
+	}
+	raw := entitymodel.APITokenPrefix + random
+
+	token := &entitymodel.APIToken{
+		UserID:      user.ID,
+		Name:        strings.TrimSpace(req.Name),
+		TokenHash:   hash.HashToken(raw),
+		TokenPrefix: raw[:apiTokenPrefixLength],
+		Scopes:      uniqueScopes(req.Scopes),
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	token, err = s.tokenRepo.Create(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("api token created",
+		zap.String("user_id", user.ID.String()),
+		zap.String("token_id", token.ID.String()),
+		zap.Strings("scopes", token.Scopes),
+	)
+
+	return &apimodel.APITokenCreated{
+		APIToken: *converter.APITokenEntityToAPI(token),
+		Token:    raw,
+	}, nil
+}
+
+func (s *apiTokenService) List(ctx context.Context, user *entitymodel.User) ([]*apimodel.APIToken, error) {
+	tokens, err := s.tokenRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*apimodel.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, converter.APITokenEntityToAPI(token))
+	}
+
+	return resp, nil
+}
+
+func (s *apiTokenService) Revoke(ctx context.Context, user *entitymodel.User, id uuid.UUID) error {
+	err := s.tokenRepo.Delete(ctx, user.ID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAPITokenNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("api token revoked", zap.String("user_id", user.ID.String()), zap.String("token_id", id.String()))
+	return nil
+}
+
+// Authenticate находит владельца токена. Токены не зависят от tokens_valid_after:
+// смена пароля не должна ломать интеграции, для этого токен отзывают явно.
+func (s *apiTokenService) Authenticate(
+	ctx context.Context,
+	raw string,
+) (*entitymodel.User, *entitymodel.APIToken, error) {
+	token, err := s.tokenRepo.GetByHash(ctx, hash.HashToken(raw))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if token.IsExpired(time.Now()) {
+		return nil, nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, nil, ErrTokenRevoked
+	}
+	// Отключённый аккаунт теряет доступ и по персональным токенам
+	if !user.IsActive {
+		return nil, nil, ErrUserInactive
+	}
+
+	if err := s.tokenRepo.TouchLastUsed(ctx, token.ID, apiTokenTouchInterval); err != nil {
+		s.log.Warn("failed to update api token usage", zap.String("token_id", token.ID.String()), zap.Error(err))
+	}
+
+	return user, token, nil
+}
+
+// uniqueScopes убирает повторы, сохраняя порядок
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result
+}
@@ -4,30 +4,51 @@ import (
 	"backend_go/internal/model/apimodel"
 	"backend_go/internal/model/entitymodel"
 	"backend_go/internal/repository"
-	"backend_go/pkg/hash"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"net/url"
+	"strings"
 )
 
 type AuthServiceImpl struct {
-	userRepo   repository.UserRepository
-	jwtService JWTService
-	log        *zap.Logger
+	userRepo            repository.UserRepository
+	jwtService          JWTService
+	verificationService VerificationService
+	passwordService     PasswordService
+	loginProtection     LoginProtectionService
+	mfaService          MFAService
+	log                 *zap.Logger
 }
 
-func NewAuthService(userRepo repository.UserRepository, jwtService JWTService, log *zap.Logger) *AuthServiceImpl {
+func NewAuthService(
+	userRepo repository.UserRepository,
+	jwtService JWTService,
+	verificationService VerificationService,
+	passwordService PasswordService,
+	loginProtection LoginProtectionService,
+	mfaService MFAService,
+	log *zap.Logger,
+) *AuthServiceImpl {
 	return &AuthServiceImpl{
-		userRepo:   userRepo,
-		jwtService: jwtService,
-		log:        log,
+		userRepo:            userRepo,
+		jwtService:          jwtService,
+		verificationService: verificationService,
+		passwordService:     passwordService,
+		loginProtection:     loginProtection,
+		mfaService:          mfaService,
+		log:                 log,
 	}
 }
 
 func (s *AuthServiceImpl) Register(ctx context.Context, req *apimodel.UserRegister) (*apimodel.TokenResponse, error) {
+	if err := s.passwordService.Validate("password", req.Password); err != nil {
+		return nil, err
+	}
+
 	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
 
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -35,10 +56,10 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req *apimodel.UserRegist
 	}
 
 	if existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", req.Email)
+		return nil, ErrEmailTaken
 	}
 
-	hashedPassword, err := hash.HashPassword(req.Password)
+	hashedPassword, err := s.passwordService.Hash(req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -60,34 +81,47 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req *apimodel.UserRegist
 		return nil, err
 	}
 
+	// Ошибка отправки письма не должна ломать регистрацию: письмо можно запросить повторно
+	if err := s.verificationService.SendVerification(ctx, createdUser); err != nil {
+		s.log.Warn("failed to send verification email", zap.String("user_id", createdUser.ID.String()), zap.Error(err))
+	}
+
 	tokens, err := s.jwtService.GenerateTokenPair(createdUser.ID.String(), createdUser.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
-	return &apimodel.TokenResponse{
-		AccessToken:  tokens["access_token"],
-		RefreshToken: tokens["refresh_token"],
-		TokenType:    tokens["token_type"],
-	}, nil
+	return newTokenResponse(tokens), nil
 }
 
-func (s *AuthServiceImpl) Login(ctx context.Context, req *apimodel.UserLogin) (*apimodel.TokenResponse, error) {
-	user, err := s.userRepo.GetByEmail(ctx, req.Email)
-	if err != nil {
-		s.log.Info("failed to find user by email", zap.String("email", req.Email), zap.Error(err))
+// Login проверяет email и пароль. Неудачные попытки учитываются LoginProtectionService,
+// после серии неудач вход временно блокируется.
+func (s *AuthServiceImpl) Login(ctx context.Context, req *apimodel.UserLogin, clientIP string) (*apimodel.LoginResponse, error) {
+	if err := s.loginProtection.Check(ctx, req.Email, clientIP); err != nil {
+		s.log.Info("login attempt while locked", zap.String("email", req.Email), zap.String("ip", clientIP))
 		return nil, err
 	}
 
-	err = hash.CheckPassword(req.Password, user.HashedPassword)
+	user, err := s.authenticate(ctx, req)
+	if errors.Is(err, ErrInvalidCredentials) {
+		s.loginProtection.RegisterFailure(ctx, req.Email, clientIP)
+		return nil, err
+	}
 	if err != nil {
-		s.log.Info("failed to check password", zap.String("email", req.Email), zap.Error(err))
 		return nil, err
 	}
 
+	s.loginProtection.RegisterSuccess(ctx, req.Email)
+
 	if user.IsActive != true {
 		s.log.Info("user is not active", zap.String("email", req.Email))
-		return nil, fmt.Errorf("user is not active")
+		return nil, ErrUserInactive
+	}
+
+	s.rehashPassword(ctx, user, req.Password)
+
+	if user.MFAEnabled {
+		return s.mfaService.Challenge(user)
 	}
 
 	tokens, err := s.jwtService.GenerateTokenPair(user.ID.String(), user.Email)
@@ -95,29 +129,271 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req *apimodel.UserLogin) (*
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
-	return &apimodel.TokenResponse{
-		AccessToken:  tokens["access_token"],
-		RefreshToken: tokens["refresh_token"],
-		TokenType:    tokens["token_type"],
-	}, nil
+	return &apimodel.LoginResponse{TokenResponse: newTokenResponse(tokens)}, nil
 }
 
-func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*entitymodel.User, error) {
-	userIDStr, err := s.jwtService.ExtractUserIDFromToken(token)
+// GuestLogin создаёт гостевого пользователя без email и пароля
+func (s *AuthServiceImpl) GuestLogin(ctx context.Context, req *apimodel.GuestLogin) (*apimodel.TokenResponse, error) {
+	_, tokens, err := s.CreateGuest(ctx, req.Name)
+	return tokens, err
+}
+
+// CreateGuest создаёт гостя и выдаёт ему токены. Возвращает и самого пользователя,
+// чтобы вызывающий мог сразу действовать от его имени, например войти в сессию по приглашению.
+func (s *AuthServiceImpl) CreateGuest(ctx context.Context, name string) (*entitymodel.User, *apimodel.TokenResponse, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil, ErrInvalidName
+	}
+
+	newUser := entitymodel.User{
+		Name:       name,
+		IsActive:   true,
+		IsVerified: false,
+		IsGuest:    true,
+		IsCreator:  false,
+		IsWatcher:  false,
+		OnSession:  true,
+	}
+
+	createdUser, err := s.userRepo.Create(ctx, &newUser)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.jwtService.GenerateTokenPair(createdUser.ID.String(), "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	s.log.Info("guest user created", zap.String("user_id", createdUser.ID.String()))
+	return createdUser, newTokenResponse(tokens), nil
+}
+
+// UpgradeGuest задаёт гостю email и пароль. id пользователя сохраняется вместе со всей историей,
+// гостевые токены отзываются и выдаётся новая пара.
+func (s *AuthServiceImpl) UpgradeGuest(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.GuestUpgrade,
+) (*apimodel.TokenResponse, error) {
+	if !user.IsGuest {
+		return nil, ErrNotGuest
+	}
+
+	name := user.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrInvalidName
+		}
+	}
+
+	if err := s.passwordService.Validate("password", req.Password); err != nil {
+		return nil, err
+	}
+
+	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if existingUser != nil {
+		return nil, ErrEmailTaken
+	}
+
+	hashedPassword, err := s.passwordService.Hash(req.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	if err := s.userRepo.UpgradeGuest(ctx, user.ID, name, req.Email, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.RevokeTokens(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	upgradedUser, err := s.userRepo.GetByID(ctx, user.ID)
 	if err != nil {
-		s.log.Info("failed to parse userID from token", zap.String("token", token))
 		return nil, err
 	}
 
+	if err := s.verificationService.SendVerification(ctx, upgradedUser); err != nil {
+		s.log.Warn("failed to send verification email", zap.String("user_id", upgradedUser.ID.String()), zap.Error(err))
+	}
+
+	tokens, err := s.jwtService.GenerateTokenPair(upgradedUser.ID.String(), upgradedUser.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	s.log.Info("guest upgraded to registered user", zap.String("user_id", upgradedUser.ID.String()))
+	return newTokenResponse(tokens), nil
+}
+
+func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*entitymodel.User, error) {
+	claims, err := s.jwtService.ParseAccessToken(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		s.log.Info("failed to parse userID from token", zap.String("token", token))
+		return nil, ErrInvalidToken
+	}
+
 	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if user.IsDeleted() {
+		return nil, ErrTokenRevoked
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	if claims.IssuedAt != nil && user.IsTokenRevoked(claims.IssuedAt.Time) {
+		s.log.Info("revoked token used", zap.String("user_id", user.ID.String()))
+		return nil, ErrTokenRevoked
+	}
+
 	return user, nil
 }
+
+// UpdateProfile меняет имя и/или аватар пользователя
+func (s *AuthServiceImpl) UpdateProfile(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.UserProfileUpdate,
+) (*entitymodel.User, error) {
+	name := user.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrInvalidName
+		}
+	}
+
+	avatarURL := user.AvatarURL
+	if req.AvatarURL != nil {
+		avatarURL = nil
+		if value := strings.TrimSpace(*req.AvatarURL); value != "" {
+			if !isHTTPURL(value) {
+				return nil, ErrInvalidAvatarURL
+			}
+			avatarURL = &value
+		}
+	}
+
+	if err := s.userRepo.UpdateProfile(ctx, user.ID, name, avatarURL); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.GetByID(ctx, user.ID)
+}
+
+// ChangePassword меняет пароль после проверки текущего, отзывает остальные токены и выдаёт новую пару
+func (s *AuthServiceImpl) ChangePassword(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.PasswordChange,
+) (*apimodel.TokenResponse, error) {
+	if user.HashedPassword == "" || s.passwordService.Check(req.CurrentPassword, user.HashedPassword) != nil {
+		s.log.Info("failed to check current password", zap.String("user_id", user.ID.String()))
+		return nil, ErrInvalidCurrentPassword
+	}
+
+	if err := s.passwordService.Validate("new_password", req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.passwordService.Hash(req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.RevokeTokens(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.jwtService.GenerateTokenPair(user.ID.String(), user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	s.log.Info("password changed", zap.String("user_id", user.ID.String()))
+	return newTokenResponse(tokens), nil
+}
+
+// authenticate находит пользователя по email и проверяет пароль
+func (s *AuthServiceImpl) authenticate(ctx context.Context, req *apimodel.UserLogin) (*entitymodel.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.log.Info("failed to find user by email", zap.String("email", req.Email))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// У OAuth-пользователей пароля может не быть
+	if user.HashedPassword == "" {
+		s.log.Info("user has no password", zap.String("email", req.Email))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.passwordService.Check(req.Password, user.HashedPassword); err != nil {
+		s.log.Info("failed to check password", zap.String("email", req.Email), zap.Error(err))
+		return nil, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+// rehashPassword пересчитывает хеш, созданный устаревшим алгоритмом или с устаревшими параметрами.
+// Ошибки не мешают входу: хеш будет пересчитан при следующем входе.
+func (s *AuthServiceImpl) rehashPassword(ctx context.Context, user *entitymodel.User, password string) {
+	if !s.passwordService.NeedsRehash(user.HashedPassword) {
+		return
+	}
+
+	newHash, err := s.passwordService.Hash(password)
+	if err != nil {
+		s.log.Warn("failed to rehash password", zap.String("user_id", user.ID.String()), zap.Error(err))
+		return
+	}
+
+	if err := s.userRepo.RehashPassword(ctx, user.ID, user.HashedPassword, newHash); err != nil {
+		s.log.Warn("failed to save rehashed password", zap.String("user_id", user.ID.String()), zap.Error(err))
+		return
+	}
+
+	s.log.Info("password rehashed", zap.String("user_id", user.ID.String()))
+}
+
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func newTokenResponse(tokens map[string]string) *apimodel.TokenResponse {
+	return &apimodel.TokenResponse{
+		AccessToken:  tokens["access_token"],
+		RefreshToken: tokens["refresh_token"],
+		TokenType:    tokens["token_type"],
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrorKind - категория доменной ошибки. По ней middleware.ErrorHandler выбирает HTTP-статус.
+type ErrorKind int
+
+const (
+	KindInvalid ErrorKind = iota + 1
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindUnprocessable
+	KindTooManyRequests
+)
+
+// FieldError - ошибка валидации конкретного поля запроса
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error - доменная ошибка со стабильным машиночитаемым кодом.
+// Message безопасно показывать клиенту, внутренние детали в него не попадают.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Fields  []FieldError
+	// RetryAfter - через сколько клиенту имеет смысл повторить запрос, для KindTooManyRequests
+	RetryAfter time.Duration
+}
+
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is сравнивает ошибки по коду, чтобы errors.Is работал и для копий с заполненными Fields
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// WithFields возвращает копию ошибки с ошибками полей
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	copied := *e
+	copied.Fields = fields
+	return &copied
+}
+
+// WithRetryAfter возвращает копию ошибки с временем, через которое можно повторить запрос
+func (e *Error) WithRetryAfter(retryAfter time.Duration) *Error {
+	copied := *e
+	copied.RetryAfter = retryAfter
+	return &copied
+}
+
+// Общие ошибки
+var (
+	ErrInvalidRequest = NewError(KindInvalid, "invalid_request", "invalid request body")
+	ErrInvalidCursor  = NewError(KindInvalid, "invalid_cursor", "invalid or outdated pagination cursor")
+	ErrValidation     = NewError(KindUnprocessable, "validation_failed", "request validation failed")
+	ErrUnauthorized   = NewError(KindUnauthorized, "unauthorized", "authorization header is required")
+	ErrInvalidToken   = NewError(KindUnauthorized, "invalid_token", "invalid or expired token")
+	ErrTokenRevoked   = NewError(KindUnauthorized, "token_revoked", "token has been revoked")
+	ErrNotFound       = NewError(KindNotFound, "not_found", "resource not found")
+	ErrForbidden      = NewError(KindForbidden, "forbidden", "you do not have permission to perform this action")
+	ErrRateLimited    = NewError(KindTooManyRequests, "rate_limited", "too many requests, try again later")
+)
+
+// Аутентификация и аккаунт
+var (
+	ErrInvalidCredentials     = NewError(KindUnauthorized, "invalid_credentials", "invalid email or password")
+	ErrUserInactive           = NewError(KindForbidden, "user_inactive", "user is not active")
+	ErrTooManyLoginAttempts   = NewError(KindTooManyRequests, "too_many_login_attempts", "too many failed login attempts, try again later")
+	ErrEmailTaken             = NewError(KindConflict, "email_taken", "user with this email already exists")
+	ErrInvalidName            = NewError(KindUnprocessable, "invalid_name", "name must not be empty")
+	ErrInvalidAvatarURL       = NewError(KindUnprocessable, "invalid_avatar_url", "avatar url must be an absolute http(s) url")
+	ErrInvalidCurrentPassword = NewError(KindInvalid, "invalid_current_password", "current password is incorrect")
+	ErrNotGuest               = NewError(KindConflict, "not_guest", "user is not a guest")
+	ErrWeakPassword           = NewError(KindUnprocessable, "weak_password", "password does not meet the password policy")
+	ErrBreachedPassword       = NewError(KindUnprocessable, "breached_password", "password has appeared in a data breach, choose another one")
+)
+
+// Двухфакторная аутентификация
+var (
+	ErrMFAAlreadyEnabled = NewError(KindConflict, "mfa_already_enabled", "two-factor authentication is already enabled")
+	ErrMFANotEnabled     = NewError(KindConflict, "mfa_not_enabled", "two-factor authentication is not enabled")
+	ErrMFANotEnrolled    = NewError(KindConflict, "mfa_not_enrolled", "two-factor enrollment has not been started")
+	ErrInvalidMFACode    = NewError(KindUnauthorized, "invalid_mfa_code", "invalid two-factor code")
+	ErrInvalidMFAToken   = NewError(KindUnauthorized, "invalid_mfa_token", "invalid or expired two-factor challenge")
+)
+
+// Персональные токены доступа
+var (
+	ErrAPITokenNotFound   = NewError(KindNotFound, "api_token_not_found", "api token not found")
+	ErrTooManyAPITokens   = NewError(KindConflict, "too_many_api_tokens", "api token limit reached, revoke unused tokens first")
+	ErrInsufficientScope  = NewError(KindForbidden, "insufficient_scope", "api token does not have the required scope")
+	ErrAPITokenNotAllowed = NewError(KindForbidden, "api_token_not_allowed", "this endpoint cannot be used with an api token")
+)
+
+// Подтверждение email и сброс пароля
+var (
+	ErrInvalidVerificationToken = NewError(KindInvalid, "invalid_verification_token", "invalid or expired verification token")
+	ErrEmailAlreadyVerified     = NewError(KindConflict, "email_already_verified", "email is already verified")
+	ErrEmailRequired            = NewError(KindUnprocessable, "email_required", "user has no email")
+	ErrInvalidResetToken        = NewError(KindInvalid, "invalid_reset_token", "invalid or expired password reset token")
+)
+
+// OAuth
+var (
+	ErrUnknownOAuthProvider = NewError(KindNotFound, "oauth_provider_not_found", "unknown oauth provider")
+	ErrInvalidOAuthState    = NewError(KindInvalid, "invalid_oauth_state", "invalid or expired oauth state")
+	ErrOAuthDenied          = NewError(KindInvalid, "oauth_access_denied", "authorization was denied by the provider")
+	ErrOAuthExchangeFailed  = NewError(KindUnauthorized, "oauth_exchange_failed", "failed to complete authorization with the provider")
+	ErrOAuthEmailRequired   = NewError(KindUnprocessable, "oauth_email_required", "oauth provider did not return a verified email")
+	ErrOAuthAccountLinked   = NewError(KindConflict, "oauth_account_linked", "account with this email is already linked to another oauth login")
+)
+
+// Сессии
+var (
+	ErrEmailNotVerified     = NewError(KindForbidden, "email_not_verified", "email must be verified to create sessions")
+	ErrSessionNameEmpty     = NewError(KindUnprocessable, "session_name_required", "session name is required")
+	ErrSessionDeckTypeEmpty = NewError(KindUnprocessable, "deck_type_required", "deck type is required")
+	ErrGuestForbidden       = NewError(KindForbidden, "guest_forbidden", "guest users cannot perform this action")
+	ErrSessionNotFound      = NewError(KindNotFound, "session_not_found", "session not found")
+	ErrCardsAlreadyRevealed = NewError(KindConflict, "cards_already_revealed", "cards are already revealed")
+)
+
+// Задачи и шаблоны
+var (
+	ErrSessionStoryNotFound    = NewError(KindNotFound, "session_story_not_found", "story not found in this session")
+	ErrSessionStoriesChanged   = NewError(KindConflict, "session_stories_changed", "stories are being added concurrently, try again")
+	ErrInvalidEstimate         = NewError(KindUnprocessable, "invalid_estimate", "estimate is not a card of the session deck")
+	ErrSessionTemplateNotFound = NewError(KindNotFound, "session_template_not_found", "session template not found")
+	ErrTooManySessionTemplates = NewError(KindConflict, "too_many_session_templates", "session template limit reached, delete unused templates first")
+)
+
+// Коды входа и приглашения
+var (
+	ErrJoinCodeNotFound   = NewError(KindNotFound, "join_code_not_found", "no session with this join code")
+	ErrInvalidInvite      = NewError(KindNotFound, "invite_not_found", "invite link is invalid or has been revoked")
+	ErrInviteExpired      = NewError(KindForbidden, "invite_expired", "invite link has expired")
+	ErrInviteExhausted    = NewError(KindForbidden, "invite_exhausted", "invite link has reached its usage limit")
+	ErrInviteNameRequired = NewError(KindUnprocessable, "invite_name_required", "name is required to join as a guest")
+)
+
+// Ограничения входа в сессию
+var (
+	ErrSessionPasscodeRequired = NewError(KindForbidden, "session_passcode_required", "this session requires a passcode")
+	ErrInvalidSessionPasscode  = NewError(KindForbidden, "invalid_session_passcode", "invalid session passcode")
+	ErrTooManyPasscodeAttempts = NewError(KindTooManyRequests, "too_many_passcode_attempts", "too many wrong session passcodes, try again later")
+	ErrEmailDomainNotAllowed   = NewError(KindForbidden, "email_domain_not_allowed", "your email domain is not allowed in this session")
+	ErrSessionEmailNotVerified = NewError(KindForbidden, "session_email_not_verified", "verify your email to join this session")
+)
+
+// Роли
+var (
+	ErrNotSessionParticipant   = NewError(KindForbidden, "not_session_participant", "you are not a participant of this session")
+	ErrInsufficientSessionRole = NewError(KindForbidden, "insufficient_session_role", "your session role does not allow this action")
+	ErrParticipantNotFound     = NewError(KindNotFound, "participant_not_found", "participant not found")
+	ErrFacilitatorRoleChange   = NewError(KindUnprocessable, "facilitator_role_change", "facilitator role cannot be changed here")
+	ErrAlreadyCoFacilitator    = NewError(KindConflict, "already_co_facilitator", "participant already manages the session")
+	ErrNotCoFacilitator        = NewError(KindConflict, "not_co_facilitator", "participant is not a co-facilitator")
+	ErrCannotTargetSelf        = NewError(KindUnprocessable, "cannot_target_self", "this action cannot be applied to yourself")
+	ErrSessionRoleConflict     = NewError(KindConflict, "session_role_conflict", "participant role was changed concurrently, try again")
+	ErrCannotRemoveFacilitator = NewError(KindUnprocessable, "cannot_remove_facilitator", "facilitator cannot be removed, transfer the role first")
+	ErrKickedFromSession       = NewError(KindForbidden, "kicked_from_session", "you were removed from this session, join again to continue")
+	ErrBannedFromSession       = NewError(KindForbidden, "banned_from_session", "you are banned from this session")
+	ErrSessionBanNotFound      = NewError(KindNotFound, "session_ban_not_found", "user is not banned in this session")
+	ErrUserNotFound            = NewError(KindNotFound, "user_not_found", "user not found")
+	ErrCannotChangeOwnRole     = NewError(KindUnprocessable, "cannot_change_own_role", "you cannot change your own role")
+	ErrRoleNotAllowed          = NewError(KindUnprocessable, "role_not_allowed", "role does not match the account type")
+)
@@ -5,21 +5,145 @@ import (
 	"backend_go/internal/model/entitymodel"
 	"context"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"time"
 )
 
 type AuthService interface {
 	Register(ctx context.Context, req *apimodel.UserRegister) (*apimodel.TokenResponse, error)
-	Login(ctx context.Context, req *apimodel.UserLogin) (*apimodel.TokenResponse, error)
+	Login(ctx context.Context, req *apimodel.UserLogin, clientIP string) (*apimodel.LoginResponse, error)
+	GuestLogin(ctx context.Context, req *apimodel.GuestLogin) (*apimodel.TokenResponse, error)
+	CreateGuest(ctx context.Context, name string) (*entitymodel.User, *apimodel.TokenResponse, error)
+	UpgradeGuest(ctx context.Context, user *entitymodel.User, req *apimodel.GuestUpgrade) (*apimodel.TokenResponse, error)
 	ValidateToken(ctx context.Context, token string) (*entitymodel.User, error)
+	UpdateProfile(ctx context.Context, user *entitymodel.User, req *apimodel.UserProfileUpdate) (*entitymodel.User, error)
+	ChangePassword(ctx context.Context, user *entitymodel.User, req *apimodel.PasswordChange) (*apimodel.TokenResponse, error)
+}
+
+// LoginProtectionService отслеживает неудачные попытки входа по email и IP
+type LoginProtectionService interface {
+	Check(ctx context.Context, email, clientIP string) error
+	RegisterFailure(ctx context.Context, email, clientIP string)
+	RegisterSuccess(ctx context.Context, email string)
+}
+
+// PasscodeProtectionService защищает коды-пароли сессий от перебора
+type PasscodeProtectionService interface {
+	Check(ctx context.Context, sessionID, userID, clientIP string) error
+	RegisterFailure(ctx context.Context, sessionID, userID, clientIP string)
+	RegisterSuccess(ctx context.Context, sessionID, userID string)
+}
+
+type OAuthService interface {
+	StartAuth(ctx context.Context, provider string) (string, error)
+	HandleCallback(ctx context.Context, provider, state, code string) (*apimodel.LoginResponse, error)
+}
+
+// MFAService - двухфакторная аутентификация по TOTP с кодами восстановления
+type MFAService interface {
+	Enroll(ctx context.Context, user *entitymodel.User) (*apimodel.MFAEnrollment, error)
+	Confirm(ctx context.Context, user *entitymodel.User, code string, clientIP string) (*apimodel.MFARecoveryCodes, error)
+	Disable(ctx context.Context, user *entitymodel.User, code string, clientIP string) error
+	Challenge(user *entitymodel.User) (*apimodel.LoginResponse, error)
+	Verify(ctx context.Context, req *apimodel.MFAVerify, clientIP string) (*apimodel.TokenResponse, error)
+}
+
+// APITokenService управляет персональными токенами доступа для ботов и интеграций
+type APITokenService interface {
+	Create(ctx context.Context, user *entitymodel.User, req *apimodel.APITokenCreate) (*apimodel.APITokenCreated, error)
+	List(ctx context.Context, user *entitymodel.User) ([]*apimodel.APIToken, error)
+	Revoke(ctx context.Context, user *entitymodel.User, id uuid.UUID) error
+	Authenticate(ctx context.Context, token string) (*entitymodel.User, *entitymodel.APIToken, error)
 }
 
 type JWTService interface {
 	GenerateTokenPair(userID string, email string) (map[string]string, error)
 	ValidateToken(tokenString string) (*jwt.Token, error)
 	ExtractUserIDFromToken(tokenString string) (string, error)
+	ParseAccessToken(tokenString string) (*CustomClaims, error)
 	RefreshToken(refreshToken string) (map[string]string, error)
+	GeneratePurposeToken(purpose, userID, email string, ttl time.Duration) (string, error)
+	ParsePurposeToken(tokenString, purpose string) (*CustomClaims, error)
+}
+
+type AccountService interface {
+	DeleteAccount(ctx context.Context, user *entitymodel.User) error
+	ExportData(ctx context.Context, user *entitymodel.User) (*apimodel.UserDataExport, error)
+}
+
+// PasswordService проверяет политику паролей и хеширует их
+type PasswordService interface {
+	Validate(field, password string) error
+	Hash(password string) (string, error)
+	Check(password, hashedPassword string) error
+	NeedsRehash(hashedPassword string) bool
+}
+
+type PasswordResetService interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type VerificationService interface {
+	SendVerification(ctx context.Context, user *entitymodel.User) error
+	VerifyEmail(ctx context.Context, token string) error
 }
 
 type SessionService interface {
-	GetUserSession(ctx context.Context, userId string) ([]*entitymodel.Session, error)
+	GetUserSession(ctx context.Context, userId string, query *apimodel.SessionListQuery) (*apimodel.SessionPage, error)
+	Archive(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error)
+	Unarchive(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error)
+	CreateSession(ctx context.Context, user *entitymodel.User, req *apimodel.SessionCreate) (*entitymodel.Session, error)
+	CloneSession(ctx context.Context, user *entitymodel.User, actor *entitymodel.SessionParticipant, req *apimodel.SessionClone) (*entitymodel.Session, error)
+	GetSession(ctx context.Context, id string) (*entitymodel.Session, error)
+	// GetParticipant возвращает ErrSessionNotFound или ErrNotSessionParticipant, если доступа нет
+	GetParticipant(ctx context.Context, sessionID, userID string) (*entitymodel.SessionParticipant, error)
+	Join(ctx context.Context, user *entitymodel.User, sessionID string, req *apimodel.SessionJoin, clientIP string) (*entitymodel.SessionParticipant, error)
+	// JoinByInvite не требует кода-пароля: приглашение выдал ведущий
+	JoinByInvite(ctx context.Context, user *entitymodel.User, sessionID string, asObserver bool) (*entitymodel.SessionParticipant, error)
+	JoinByCode(ctx context.Context, user *entitymodel.User, req *apimodel.SessionJoinByCode, clientIP string) (*entitymodel.SessionParticipant, error)
+	RegenerateJoinCode(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error)
+	UpdateAccess(ctx context.Context, actor *entitymodel.SessionParticipant, req *apimodel.SessionAccessUpdate) (*entitymodel.Session, error)
+	ListParticipants(ctx context.Context, sessionID string) ([]*entitymodel.SessionParticipant, error)
+	SetParticipantRole(ctx context.Context, actor *entitymodel.SessionParticipant, userID, role string) (*entitymodel.SessionParticipant, error)
+	TransferFacilitator(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) (*entitymodel.SessionParticipant, error)
+	AddCoFacilitator(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) (*entitymodel.SessionParticipant, error)
+	RemoveCoFacilitator(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) (*entitymodel.SessionParticipant, error)
+	RevealCards(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error)
+	ResetVotes(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error)
+	ListEvents(ctx context.Context, sessionID string) ([]*entitymodel.SessionEvent, error)
+	Kick(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) error
+	Ban(ctx context.Context, actor *entitymodel.SessionParticipant, req *apimodel.SessionBanCreate) (*entitymodel.SessionBan, error)
+	Unban(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) error
+	ListBans(ctx context.Context, sessionID string) ([]*entitymodel.SessionBan, error)
+}
+
+// InviteService - приглашения в сессию по подписанной ссылке
+type InviteService interface {
+	Create(ctx context.Context, actor *entitymodel.SessionParticipant, req *apimodel.SessionInviteCreate) (*apimodel.SessionInvite, error)
+	List(ctx context.Context, sessionID string) ([]*apimodel.SessionInvite, error)
+	Revoke(ctx context.Context, actor *entitymodel.SessionParticipant, inviteID string) error
+	Preview(ctx context.Context, token string) (*apimodel.SessionInvitePreview, error)
+	// Accept принимает user == nil: тогда по имени из запроса создаётся гость
+	Accept(ctx context.Context, user *entitymodel.User, token string, req *apimodel.SessionInviteAccept) (*apimodel.SessionInviteAccepted, error)
+}
+
+// StoryService - задачи для оценки в сессии
+type StoryService interface {
+	List(ctx context.Context, sessionID string) ([]*entitymodel.SessionStory, error)
+	Create(ctx context.Context, actor *entitymodel.SessionParticipant, req *apimodel.SessionStoryCreate) (*entitymodel.SessionStory, error)
+	SetEstimate(ctx context.Context, actor *entitymodel.SessionParticipant, storyID, estimate string) (*entitymodel.SessionStory, error)
+}
+
+// TemplateService - личные шаблоны сессий
+type TemplateService interface {
+	List(ctx context.Context, user *entitymodel.User) ([]*entitymodel.SessionTemplate, error)
+	Create(ctx context.Context, user *entitymodel.User, req *apimodel.SessionTemplateSave) (*entitymodel.SessionTemplate, error)
+	Update(ctx context.Context, user *entitymodel.User, id string, req *apimodel.SessionTemplateSave) (*entitymodel.SessionTemplate, error)
+	Delete(ctx context.Context, user *entitymodel.User, id string) error
+}
+
+// AdminService - действия, доступные только администраторам
+type AdminService interface {
+	SetUserRole(ctx context.Context, actor *entitymodel.User, userID uuid.UUID, role string) (*entitymodel.User, error)
 }
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"backend_go/pkg/hash"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// inviteSignaturePurpose отделяет подписи приглашений от других подписей тем же секретом
+const inviteSignaturePurpose = "session_invite:"
+
+type inviteService struct {
+	inviteRepo     repository.SessionInviteRepository
+	sessionService SessionService
+	authService    AuthService
+	secret         []byte
+	baseURL        string
+	log            *zap.Logger
+}
+
+func NewInviteService(
+	inviteRepo repository.SessionInviteRepository,
+	sessionService SessionService,
+	authService AuthService,
+	secret string,
+	baseURL string,
+	log *zap.Logger,
+) *inviteService {
+	return &inviteService{
+		inviteRepo:     inviteRepo,
+		sessionService: sessionService,
+		authService:    authService,
+		secret:         []byte(secret),
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		log:            log,
+	}
+}
+
+func (s *inviteService) Create(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	req *apimodel.SessionInviteCreate,
+) (*apimodel.SessionInvite, error) {
+	invite := &entitymodel.SessionInvite{
+		SessionID: actor.SessionID,
+		CreatedBy: &actor.UserID,
+		MaxUses:   req.MaxUses,
+	}
+	if req.ExpiresInHours != nil {
+		expiresAt := time.Now().Add(time.Duration(*req.ExpiresInHours) * time.Hour)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	created, err := s.inviteRepo.Create(ctx, invite)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session invite created",
+		zap.String("session_id", actor.SessionID),
+		zap.String("invite_id", created.ID),
+		zap.String("actor_id", actor.UserID),
+	)
+	return s.toAPI(created), nil
+}
+
+func (s *inviteService) List(ctx context.Context, sessionID string) ([]*apimodel.SessionInvite, error) {
+	invites, err := s.inviteRepo.ListBySession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*apimodel.SessionInvite, 0, len(invites))
+	for _, invite := range invites {
+		resp = append(resp, s.toAPI(invite))
+	}
+	return resp, nil
+}
+
+// Revoke удаляет приглашение, ссылка сразу перестаёт работать
+func (s *inviteService) Revoke(ctx context.Context, actor *entitymodel.SessionParticipant, inviteID string) error {
+	err := s.inviteRepo.Delete(ctx, actor.SessionID, inviteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidInvite
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("session invite revoked",
+		zap.String("session_id", actor.SessionID),
+		zap.String("invite_id", inviteID),
+		zap.String("actor_id", actor.UserID),
+	)
+	return nil
+}
+
+// Preview показывает, в какую сессию ведёт ссылка, не засчитывая вход
+func (s *inviteService) Preview(ctx context.Context, token string) (*apimodel.SessionInvitePreview, error) {
+	invite, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionService.GetSession(ctx, invite.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.SessionInvitePreview{
+		SessionID:   session.ID,
+		SessionName: session.Name,
+		CreatorName: session.CreatorName,
+		ExpiresAt:   invite.ExpiresAt,
+	}, nil
+}
+
+// Accept впускает в сессию по ссылке. Без авторизации (user == nil) сначала создаётся гость
+// с именем из запроса, и его токены возвращаются вместе с участником.
+// Тот, кто уже в сессии, проходит без расхода лимита приглашения.
+func (s *inviteService) Accept(
+	ctx context.Context,
+	user *entitymodel.User,
+	token string,
+	req *apimodel.SessionInviteAccept,
+) (*apimodel.SessionInviteAccepted, error) {
+	invite, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil {
+		participant, err := s.sessionService.GetParticipant(ctx, invite.SessionID, user.ID.String())
+		if err == nil {
+			return s.accepted(ctx, nil, participant)
+		}
+		if errors.Is(err, ErrBannedFromSession) {
+			return nil, err
+		}
+	} else if err := s.checkGuestAllowed(ctx, invite, req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.inviteRepo.Consume(ctx, invite.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.consumeError(ctx, invite.ID)
+		}
+		return nil, err
+	}
+
+	var tokens *apimodel.TokenResponse
+	if user == nil {
+		user, tokens, err = s.authService.CreateGuest(ctx, *req.Name)
+		if err != nil {
+			s.release(ctx, invite.ID)
+			return nil, err
+		}
+	}
+
+	participant, err := s.sessionService.JoinByInvite(ctx, user, invite.SessionID, req.AsObserver)
+	if err != nil {
+		s.release(ctx, invite.ID)
+		return nil, err
+	}
+
+	s.log.Info("session invite accepted",
+		zap.String("session_id", invite.SessionID),
+		zap.String("invite_id", invite.ID),
+		zap.String("user_id", user.ID.String()),
+		zap.Bool("guest_created", tokens != nil),
+	)
+	return s.accepted(ctx, tokens, participant)
+}
+
+// checkGuestAllowed проверяет до создания гостя, что он сможет войти: иначе останется пустой аккаунт
+func (s *inviteService) checkGuestAllowed(
+	ctx context.Context,
+	invite *entitymodel.SessionInvite,
+	req *apimodel.SessionInviteAccept,
+) error {
+	if req.Name == nil {
+		return ErrInviteNameRequired
+	}
+
+	session, err := s.sessionService.GetSession(ctx, invite.SessionID)
+	if err != nil {
+		return err
+	}
+	if session.IsRestrictedByDomain() {
+		return ErrEmailDomainNotAllowed
+	}
+	return nil
+}
+
+// resolve проверяет подпись ссылки и то, что приглашение ещё действует
+func (s *inviteService) resolve(ctx context.Context, token string) (*entitymodel.SessionInvite, error) {
+	inviteID, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidInvite
+	}
+	if _, err := uuid.Parse(inviteID); err != nil {
+		return nil, ErrInvalidInvite
+	}
+	if !hash.VerifySignature(s.secret, inviteSignaturePurpose+inviteID, signature) {
+		return nil, ErrInvalidInvite
+	}
+
+	invite, err := s.inviteRepo.Get(ctx, inviteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidInvite
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkInvite(invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// consumeError объясняет, почему Consume не засчитал вход: приглашение могли отозвать
+// или исчерпать между проверкой и входом
+func (s *inviteService) consumeError(ctx context.Context, inviteID string) error {
+	invite, err := s.inviteRepo.Get(ctx, inviteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidInvite
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := checkInvite(invite); err != nil {
+		return err
+	}
+	return ErrInviteExhausted
+}
+
+// release возвращает засчитанный вход; ошибка только логируется, чтобы не скрыть исходную
+func (s *inviteService) release(ctx context.Context, inviteID string) {
+	if err := s.inviteRepo.Release(ctx, inviteID); err != nil {
+		s.log.Error("failed to release session invite", zap.String("invite_id", inviteID), zap.Error(err))
+	}
+}
+
+func (s *inviteService) accepted(
+	ctx context.Context,
+	tokens *apimodel.TokenResponse,
+	participant *entitymodel.SessionParticipant,
+) (*apimodel.SessionInviteAccepted, error) {
+	session, err := s.sessionService.GetSession(ctx, participant.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.SessionInviteAccepted{
+		Tokens:      tokens,
+		Session:     converter.SessionEntityToAPI(session),
+		Participant: converter.SessionParticipantEntityToAPI(participant),
+	}, nil
+}
+
+func (s *inviteService) toAPI(invite *entitymodel.SessionInvite) *apimodel.SessionInvite {
+	token := invite.ID + "." + hash.Sign(s.secret, inviteSignaturePurpose+invite.ID)
+	return converter.SessionInviteEntityToAPI(invite, token, s.baseURL+"/invite/"+token)
+}
+
+func checkInvite(invite *entitymodel.SessionInvite) error {
+	if invite.IsExpired(time.Now()) {
+		return ErrInviteExpired
+	}
+	if invite.IsExhausted() {
+		return ErrInviteExhausted
+	}
+	return nil
+}
@@ -19,10 +19,17 @@ func NewJwtService(cfg *config.Config, log *zap.Logger) *jwtService {
 	}
 }
 
+// Назначения одноразовых токенов. У access/refresh токенов назначение пустое.
+const (
+	PurposeEmailVerification = "email_verification"
+	PurposeMFAChallenge      = "mfa_challenge"
+)
+
 // CustomClaims - кастомные claims для нашего приложения
 type CustomClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID  string `json:"user_id"`
+	Email   string `json:"email"`
+	Purpose string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -82,16 +89,27 @@ func (s *jwtService) ValidateToken(tokenString string) (*jwt.Token, error) {
 }
 
 func (s *jwtService) ExtractUserIDFromToken(tokenString string) (string, error) {
-	token, err := s.ValidateToken(tokenString)
+	claims, err := s.ParseAccessToken(tokenString)
 	if err != nil {
 		return "", err
 	}
 
-	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
-		return claims.UserID, nil
+	return claims.UserID, nil
+}
+
+// ParseAccessToken проверяет токен авторизации и возвращает его claims
+func (s *jwtService) ParseAccessToken(tokenString string) (*CustomClaims, error) {
+	token, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Токены с назначением (например, подтверждение email) нельзя использовать для авторизации
+	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid && claims.Purpose == "" {
+		return claims, nil
 	}
 
-	return "", jwt.ErrInvalidKey
+	return nil, jwt.ErrInvalidKey
 }
 
 func (s *jwtService) RefreshToken(refreshToken string) (map[string]string, error) {
@@ -101,9 +119,43 @@ func (s *jwtService) RefreshToken(refreshToken string) (map[string]string, error
 	}
 
 	claims, ok := token.Claims.(*CustomClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.Purpose != "" {
 		return nil, jwt.ErrInvalidKey
 	}
 
 	return s.GenerateTokenPair(claims.UserID, claims.Email)
 }
+
+// GeneratePurposeToken создаёт подписанный токен с ограниченным сроком жизни для конкретного действия
+func (s *jwtService) GeneratePurposeToken(purpose, userID, email string, ttl time.Duration) (string, error) {
+	claims := CustomClaims{
+		UserID:  userID,
+		Email:   email,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    s.cfg.JWTIssuer,
+			Subject:   userID,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.cfg.JWTSecret))
+}
+
+// ParsePurposeToken проверяет подпись, срок действия и назначение токена
+func (s *jwtService) ParsePurposeToken(tokenString, purpose string) (*CustomClaims, error) {
+	token, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*CustomClaims)
+	if !ok || !token.Valid || claims.Purpose != purpose {
+		return nil, jwt.ErrInvalidKey
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"backend_go/internal/repository"
+	"context"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// LoginProtectionPolicy - пороги защиты входа от перебора паролей.
+// Для email после DelayAfter неудач вход откладывается на экспоненциально растущую паузу,
+// после LockoutAfter - блокируется на LockoutDuration. Для IP действует только блокировка
+// после IPLockoutAfter неудач: за одним адресом может быть много пользователей.
+type LoginProtectionPolicy struct {
+	DelayAfter      int
+	LockoutAfter    int
+	IPLockoutAfter  int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+type loginProtectionService struct {
+	attemptRepo repository.LoginAttemptRepository
+	policy      LoginProtectionPolicy
+	log         *zap.Logger
+}
+
+func NewLoginProtectionService(
+	attemptRepo repository.LoginAttemptRepository,
+	policy LoginProtectionPolicy,
+	log *zap.Logger,
+) *loginProtectionService {
+	return &loginProtectionService{
+		attemptRepo: attemptRepo,
+		policy:      policy,
+		log:         log,
+	}
+}
+
+// Check возвращает ErrTooManyLoginAttempts, если email или IP временно заблокированы.
+// При недоступности хранилища вход не блокируется, чтобы сбой Redis не отключил авторизацию.
+func (s *loginProtectionService) Check(ctx context.Context, email, clientIP string) error {
+	var retryAfter time.Duration
+
+	for _, key := range s.keys(email, clientIP) {
+		lockedFor, err := s.attemptRepo.LockedFor(ctx, key)
+		if err != nil {
+			s.log.Error("failed to check login lock", zap.Error(err))
+			continue
+		}
+		if lockedFor > retryAfter {
+			retryAfter = lockedFor
+		}
+	}
+
+	if retryAfter > 0 {
+		return ErrTooManyLoginAttempts.WithRetryAfter(retryAfter)
+	}
+
+	return nil
+}
+
+func (s *loginProtectionService) RegisterFailure(ctx context.Context, email, clientIP string) {
+	if key := emailKey(email); key != "" {
+		failures, err := s.attemptRepo.RegisterFailure(ctx, key, s.policy.Window)
+		if err != nil {
+			s.log.Error("failed to register login failure", zap.Error(err))
+		} else if delay, lockout := s.emailDelay(failures); delay > 0 {
+			s.lock(ctx, key, delay, lockout, failures, zap.String("email", email))
+		}
+	}
+
+	if key := ipKey(clientIP); key != "" {
+		failures, err := s.attemptRepo.RegisterFailure(ctx, key, s.policy.Window)
+		if err != nil {
+			s.log.Error("failed to register login failure", zap.Error(err))
+		} else if s.policy.IPLockoutAfter > 0 && failures >= s.policy.IPLockoutAfter {
+			s.lock(ctx, key, s.policy.LockoutDuration, true, failures, zap.String("ip", clientIP))
+		}
+	}
+}
+
+// RegisterSuccess сбрасывает счётчик email. Счётчик IP не сбрасывается,
+// иначе вход в свой аккаунт позволял бы продолжать перебор чужих с того же адреса.
+func (s *loginProtectionService) RegisterSuccess(ctx context.Context, email string) {
+	if key := emailKey(email); key != "" {
+		if err := s.attemptRepo.Reset(ctx, key); err != nil {
+			s.log.Error("failed to reset login attempts", zap.Error(err))
+		}
+	}
+}
+
+// emailDelay возвращает длительность блокировки после failures неудач и признак полной блокировки
+func (s *loginProtectionService) emailDelay(failures int) (time.Duration, bool) {
+	if s.policy.LockoutAfter > 0 && failures >= s.policy.LockoutAfter {
+		return s.policy.LockoutDuration, true
+	}
+	if s.policy.DelayAfter <= 0 || failures < s.policy.DelayAfter {
+		return 0, false
+	}
+
+	delay := s.policy.BaseDelay
+	for i := s.policy.DelayAfter; i < failures && delay < s.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.policy.MaxDelay {
+		delay = s.policy.MaxDelay
+	}
+
+	return delay, false
+}
+
+func (s *loginProtectionService) lock(
+	ctx context.Context,
+	key string,
+	duration time.Duration,
+	lockout bool,
+	failures int,
+	subject zap.Field,
+) {
+	if err := s.attemptRepo.Lock(ctx, key, duration); err != nil {
+		s.log.Error("failed to lock login", zap.Error(err))
+		return
+	}
+
+	if lockout {
+		s.log.Warn("login locked out after repeated failures",
+			subject,
+			zap.Int("failures", failures),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	s.log.Info("login delayed after failed attempt",
+		subject,
+		zap.Int("failures", failures),
+		zap.Duration("delay", duration),
+	)
+}
+
+func (s *loginProtectionService) keys(email, clientIP string) []string {
+	keys := make([]string, 0, 2)
+	if key := emailKey(email); key != "" {
+		keys = append(keys, key)
+	}
+	if key := ipKey(clientIP); key != "" {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func emailKey(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return ""
+	}
+	return "email:" + email
+}
+
+func ipKey(clientIP string) string {
+	if clientIP == "" {
+		return ""
+	}
+	return "ip:" + clientIP
+}
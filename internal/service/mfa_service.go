@@ -0,0 +1,304 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"backend_go/pkg/hash"
+	"backend_go/pkg/totp"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	recoveryCodeCount = 10
+	// recoveryCodeSize - байт случайности в коде восстановления (80 бит)
+	recoveryCodeSize = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type mfaService struct {
+	userRepo        repository.UserRepository
+	recoveryRepo    repository.MFARecoveryCodeRepository
+	jwtService      JWTService
+	loginProtection LoginProtectionService
+	issuer          string
+	challengeTTL    time.Duration
+	log             *zap.Logger
+}
+
+func NewMFAService(
+	userRepo repository.UserRepository,
+	recoveryRepo repository.MFARecoveryCodeRepository,
+	jwtService JWTService,
+	loginProtection LoginProtectionService,
+	issuer string,
+	challengeTTL time.Duration,
+	log *zap.Logger,
+) *mfaService {
+	return &mfaService{
+		userRepo:        userRepo,
+		recoveryRepo:    recoveryRepo,
+		jwtService:      jwtService,
+		loginProtection: loginProtection,
+		issuer:          issuer,
+		challengeTTL:    challengeTTL,
+		log:             log,
+	}
+}
+
+// Enroll создаёт новый секрет TOTP. 2FA включится только после подтверждения кодом в Confirm.
+func (s *mfaService) Enroll(ctx context.Context, user *entitymodel.User) (*apimodel.MFAEnrollment, error) {
+	if user.IsGuest {
+		return nil, ErrGuestForbidden
+	}
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	err = s.userRepo.SetMFASecret(ctx, user.ID, secret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.MFAEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm проверяет первый код из приложения, включает 2FA и выдаёт коды восстановления
+func (s *mfaService) Confirm(
+	ctx context.Context,
+	user *entitymodel.User,
+	code string,
+	clientIP string,
+) (*apimodel.MFARecoveryCodes, error) {
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFASecret == nil {
+		return nil, ErrMFANotEnrolled
+	}
+
+	if err := s.verifyCode(ctx, user, code, clientIP, s.checkTOTP); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepo.EnableMFA(ctx, user.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMFANotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("mfa enabled", zap.String("user_id", user.ID.String()))
+	return &apimodel.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable отключает 2FA. Требует действующий код, чтобы украденного access-токена было недостаточно.
+func (s *mfaService) Disable(ctx context.Context, user *entitymodel.User, code string, clientIP string) error {
+	if !user.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+
+	if err := s.verifyCode(ctx, user, code, clientIP, s.checkCode); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.DisableMFA(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.log.Info("mfa disabled", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// Challenge выдаёт короткоживущий токен второго шага входа вместо пары JWT
+func (s *mfaService) Challenge(user *entitymodel.User) (*apimodel.LoginResponse, error) {
+	token, err := s.jwtService.GeneratePurposeToken(PurposeMFAChallenge, user.ID.String(), user.Email, s.challengeTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa challenge token: %w", err)
+	}
+
+	return &apimodel.LoginResponse{
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+// Verify обменивает токен второго шага и код на пару JWT
+func (s *mfaService) Verify(
+	ctx context.Context,
+	req *apimodel.MFAVerify,
+	clientIP string,
+) (*apimodel.TokenResponse, error) {
+	claims, err := s.jwtService.ParsePurposeToken(req.MFAToken, PurposeMFAChallenge)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Токен второго шага теряет силу вместе с остальными при смене пароля и удалении аккаунта
+	if user.IsDeleted() || !user.IsActive || !user.MFAEnabled ||
+		(claims.IssuedAt != nil && user.IsTokenRevoked(claims.IssuedAt.Time)) {
+		return nil, ErrInvalidMFAToken
+	}
+
+	if err := s.verifyCode(ctx, user, req.Code, clientIP, s.checkCode); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.jwtService.GenerateTokenPair(user.ID.String(), user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	return newTokenResponse(tokens), nil
+}
+
+// verifyCode проверяет код через check с защитой от перебора: неверные коды учитываются
+// так же, как неверные пароли при входе, и после блокировки проверка не выполняется
+func (s *mfaService) verifyCode(
+	ctx context.Context,
+	user *entitymodel.User,
+	code string,
+	clientIP string,
+	check func(ctx context.Context, user *entitymodel.User, code string) (bool, error),
+) error {
+	if err := s.loginProtection.Check(ctx, user.Email, clientIP); err != nil {
+		return err
+	}
+
+	ok, err := check(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		s.log.Info("invalid mfa code", zap.String("user_id", user.ID.String()))
+		s.loginProtection.RegisterFailure(ctx, user.Email, clientIP)
+		return ErrInvalidMFACode
+	}
+
+	s.loginProtection.RegisterSuccess(ctx, user.Email)
+	return nil
+}
+
+// checkCode принимает код TOTP или одноразовый код восстановления
+func (s *mfaService) checkCode(ctx context.Context, user *entitymodel.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.checkTOTP(ctx, user, code)
+	}
+
+	err := s.recoveryRepo.Consume(ctx, user.ID, hash.HashToken(normalizeRecoveryCode(code)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	s.log.Info("mfa recovery code used", zap.String("user_id", user.ID.String()))
+	return true, nil
+}
+
+// checkTOTP проверяет код и запоминает его интервал, чтобы тот же код нельзя было использовать повторно
+func (s *mfaService) checkTOTP(ctx context.Context, user *entitymodel.User, code string) (bool, error) {
+	if user.MFASecret == nil {
+		return false, nil
+	}
+
+	step, ok := totp.Validate(*user.MFASecret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	err := s.userRepo.UseMFAStep(ctx, user.ID, step)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.log.Info("totp code reused", zap.String("user_id", user.ID.String()))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *mfaService) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hash.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := s.recoveryRepo.Replace(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCode возвращает код вида xxxx-xxxx-xxxx-xxxx
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+	groups := make([]string, 0, len(raw)/4)
+	for i := 0; i < len(raw); i += 4 {
+		groups = append(groups, raw[i:i+4])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// normalizeRecoveryCode убирает дефисы, пробелы и регистр, чтобы код можно было ввести как угодно
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"backend_go/internal/infrastructure/oauth"
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+type OAuthServiceImpl struct {
+	providers  *oauth.Registry
+	stateRepo  repository.OAuthStateRepository
+	userRepo   repository.UserRepository
+	jwtService JWTService
+	mfaService MFAService
+	stateTTL   time.Duration
+	log        *zap.Logger
+}
+
+func NewOAuthService(
+	providers *oauth.Registry,
+	stateRepo repository.OAuthStateRepository,
+	userRepo repository.UserRepository,
+	jwtService JWTService,
+	mfaService MFAService,
+	stateTTL time.Duration,
+	log *zap.Logger,
+) *OAuthServiceImpl {
+	return &OAuthServiceImpl{
+		providers:  providers,
+		stateRepo:  stateRepo,
+		userRepo:   userRepo,
+		jwtService: jwtService,
+		mfaService: mfaService,
+		stateTTL:   stateTTL,
+		log:        log,
+	}
+}
+
+// StartAuth генерирует state и PKCE-пару и возвращает URL авторизации у провайдера
+func (s *OAuthServiceImpl) StartAuth(ctx context.Context, providerName string) (string, error) {
+	provider, ok := s.providers.Get(providerName)
+	if !ok {
+		return "", ErrUnknownOAuthProvider
+	}
+
+	state, err := oauth.GenerateState()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+
+	verifier, err := oauth.GenerateCodeVerifier()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	nonce, err := oauth.GenerateState()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate oauth nonce: %w", err)
+	}
+
+	err = s.stateRepo.Save(ctx, state, &entitymodel.OAuthState{
+		Provider:     entitymodel.OAuthProvider(providerName),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(s.stateTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save oauth state: %w", err)
+	}
+
+	return provider.AuthCodeURL(state, oauth.CodeChallengeS256(verifier), nonce), nil
+}
+
+// HandleCallback обменивает код на токен провайдера, находит или создаёт пользователя и выдаёт пару JWT
+func (s *OAuthServiceImpl) HandleCallback(
+	ctx context.Context,
+	providerName, state, code string,
+) (*apimodel.LoginResponse, error) {
+	provider, ok := s.providers.Get(providerName)
+	if !ok {
+		return nil, ErrUnknownOAuthProvider
+	}
+
+	savedState, err := s.stateRepo.Pop(ctx, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oauth state: %w", err)
+	}
+	if savedState == nil || savedState.Provider != entitymodel.OAuthProvider(providerName) {
+		return nil, ErrInvalidOAuthState
+	}
+
+	token, err := provider.Exchange(ctx, code, savedState.CodeVerifier)
+	if err != nil {
+		s.log.Info("failed to exchange oauth code", zap.String("provider", providerName), zap.Error(err))
+		return nil, ErrOAuthExchangeFailed
+	}
+
+	info, err := provider.UserInfo(ctx, token, savedState.Nonce)
+	if err != nil {
+		s.log.Info("failed to fetch oauth user info", zap.String("provider", providerName), zap.Error(err))
+		return nil, ErrOAuthExchangeFailed
+	}
+
+	user, err := s.findOrCreateUser(ctx, entitymodel.OAuthProvider(providerName), info)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		s.log.Info("user is not active", zap.String("user_id", user.ID.String()))
+		return nil, ErrUserInactive
+	}
+
+	// Вход через провайдера не заменяет второй фактор
+	if user.MFAEnabled {
+		return s.mfaService.Challenge(user)
+	}
+
+	tokens, err := s.jwtService.GenerateTokenPair(user.ID.String(), user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	return &apimodel.LoginResponse{TokenResponse: newTokenResponse(tokens)}, nil
+}
+
+// findOrCreateUser ищет пользователя по OAuth ID, затем по подтверждённому email (с привязкой аккаунта),
+// и только после этого создаёт нового
+func (s *OAuthServiceImpl) findOrCreateUser(
+	ctx context.Context,
+	provider entitymodel.OAuthProvider,
+	info *oauth.UserInfo,
+) (*entitymodel.User, error) {
+	user, err := s.userRepo.GetByOAuth(ctx, provider, info.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if user != nil {
+		return user, nil
+	}
+
+	// Привязывать аккаунт по email можно только если провайдер подтвердил адрес
+	if info.Email == "" || !info.EmailVerified {
+		return nil, ErrOAuthEmailRequired
+	}
+
+	var avatarURL *string
+	if info.AvatarURL != "" {
+		avatarURL = &info.AvatarURL
+	}
+
+	user, err = s.userRepo.GetByEmail(ctx, info.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if user != nil {
+		// Уже привязанный аккаунт не перепривязываем: иначе любой настроенный провайдер
+		// с тем же email мог бы забрать чужую учётную запись
+		if user.OAuthProvider != nil {
+			return nil, ErrOAuthAccountLinked
+		}
+		err := s.userRepo.LinkOAuth(ctx, user.ID, provider, info.ID, avatarURL)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOAuthAccountLinked
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.log.Info("oauth account linked", zap.String("user_id", user.ID.String()), zap.String("provider", string(provider)))
+
+		return s.userRepo.GetByID(ctx, user.ID)
+	}
+
+	name := info.Name
+	if name == "" {
+		name = info.Email
+	}
+
+	oauthID := info.ID
+	newUser := entitymodel.User{
+		Name:          name,
+		Email:         info.Email,
+		IsActive:      true,
+		IsVerified:    true,
+		IsGuest:       false,
+		IsCreator:     false,
+		IsWatcher:     false,
+		OnSession:     true,
+		OAuthProvider: &provider,
+		OAuthID:       &oauthID,
+		AvatarURL:     avatarURL,
+	}
+
+	createdUser, err := s.userRepo.Create(ctx, &newUser)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("user created via oauth", zap.String("user_id", createdUser.ID.String()), zap.String("provider", string(provider)))
+
+	return createdUser, nil
+}
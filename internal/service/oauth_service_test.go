@@ -0,0 +1,94 @@
+package service
+
+import (
+	"backend_go/internal/infrastructure/oauth"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"testing"
+)
+
+// linkingUserRepo хранит одного пользователя, найденного по email, и запоминает привязки
+type linkingUserRepo struct {
+	repository.UserRepository
+	user   *entitymodel.User
+	linked []entitymodel.OAuthProvider
+}
+
+func (r *linkingUserRepo) GetByOAuth(context.Context, entitymodel.OAuthProvider, string) (*entitymodel.User, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (r *linkingUserRepo) GetByEmail(context.Context, string) (*entitymodel.User, error) {
+	return r.user, nil
+}
+
+func (r *linkingUserRepo) GetByID(context.Context, uuid.UUID) (*entitymodel.User, error) {
+	return r.user, nil
+}
+
+func (r *linkingUserRepo) LinkOAuth(
+	_ context.Context,
+	_ uuid.UUID,
+	provider entitymodel.OAuthProvider,
+	oauthID string,
+	_ *string,
+) error {
+	if r.user.OAuthProvider != nil {
+		return sql.ErrNoRows
+	}
+	r.linked = append(r.linked, provider)
+	r.user.OAuthProvider = &provider
+	r.user.OAuthID = &oauthID
+	return nil
+}
+
+func TestOAuthFindOrCreateUserLinksByEmail(t *testing.T) {
+	google := entitymodel.OAuthProvider("google")
+	googleID := "google-1"
+
+	tests := []struct {
+		name       string
+		provider   *entitymodel.OAuthProvider
+		oauthID    *string
+		login      entitymodel.OAuthProvider
+		wantErr    error
+		wantLinked int
+	}{
+		{name: "unlinked account is linked", login: "keycloak", wantLinked: 1},
+		{name: "account linked to another provider", provider: &google, oauthID: &googleID, login: "keycloak", wantErr: ErrOAuthAccountLinked},
+		{name: "account linked to another id of the same provider", provider: &google, oauthID: &googleID, login: "google", wantErr: ErrOAuthAccountLinked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &linkingUserRepo{user: &entitymodel.User{
+				ID:            uuid.New(),
+				Email:         "user@example.com",
+				IsActive:      true,
+				OAuthProvider: tt.provider,
+				OAuthID:       tt.oauthID,
+			}}
+			svc := &OAuthServiceImpl{userRepo: repo, log: zap.NewNop()}
+			info := &oauth.UserInfo{ID: "idp-1", Email: "user@example.com", EmailVerified: true}
+
+			user, err := svc.findOrCreateUser(context.Background(), tt.login, info)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("findOrCreateUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.linked) != tt.wantLinked {
+				t.Errorf("LinkOAuth() called %d times, want %d", len(repo.linked), tt.wantLinked)
+			}
+			if tt.wantErr == nil && (user.OAuthProvider == nil || *user.OAuthProvider != tt.login) {
+				t.Errorf("findOrCreateUser() provider = %v, want %q", user.OAuthProvider, tt.login)
+			}
+			if tt.wantErr != nil && *repo.user.OAuthProvider != *tt.provider {
+				t.Errorf("existing link changed to %q", *repo.user.OAuthProvider)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"backend_go/internal/repository"
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// PasscodeProtectionPolicy - пороги защиты кодов-паролей сессий от перебора.
+// Неудачи считаются для пары (сессия, пользователь) и для IP по всем сессиям:
+// гостевой аккаунт создаётся свободно, поэтому одного счётчика пользователя недостаточно.
+type PasscodeProtectionPolicy struct {
+	LockoutAfter    int
+	IPLockoutAfter  int
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+type passcodeProtectionService struct {
+	attemptRepo repository.LoginAttemptRepository
+	policy      PasscodeProtectionPolicy
+	log         *zap.Logger
+}
+
+func NewPasscodeProtectionService(
+	attemptRepo repository.LoginAttemptRepository,
+	policy PasscodeProtectionPolicy,
+	log *zap.Logger,
+) *passcodeProtectionService {
+	return &passcodeProtectionService{
+		attemptRepo: attemptRepo,
+		policy:      policy,
+		log:         log,
+	}
+}
+
+// Check возвращает ErrTooManyPasscodeAttempts, если пользователь в этой сессии или его IP заблокированы.
+// При недоступности хранилища проверка пропускается, как и при входе.
+func (s *passcodeProtectionService) Check(ctx context.Context, sessionID, userID, clientIP string) error {
+	var retryAfter time.Duration
+
+	for _, key := range s.keys(sessionID, userID, clientIP) {
+		lockedFor, err := s.attemptRepo.LockedFor(ctx, key)
+		if err != nil {
+			s.log.Error("failed to check passcode lock", zap.Error(err))
+			continue
+		}
+		if lockedFor > retryAfter {
+			retryAfter = lockedFor
+		}
+	}
+
+	if retryAfter > 0 {
+		return ErrTooManyPasscodeAttempts.WithRetryAfter(retryAfter)
+	}
+
+	return nil
+}
+
+func (s *passcodeProtectionService) RegisterFailure(ctx context.Context, sessionID, userID, clientIP string) {
+	s.registerFailure(ctx, passcodeUserKey(sessionID, userID), s.policy.LockoutAfter,
+		zap.String("session_id", sessionID), zap.String("user_id", userID))
+
+	if key := passcodeIPKey(clientIP); key != "" {
+		s.registerFailure(ctx, key, s.policy.IPLockoutAfter, zap.String("ip", clientIP))
+	}
+}
+
+// RegisterSuccess сбрасывает счётчик пользователя в сессии. Счётчик IP не сбрасывается,
+// иначе вход в свою сессию позволял бы продолжать перебор чужих с того же адреса.
+func (s *passcodeProtectionService) RegisterSuccess(ctx context.Context, sessionID, userID string) {
+	if err := s.attemptRepo.Reset(ctx, passcodeUserKey(sessionID, userID)); err != nil {
+		s.log.Error("failed to reset passcode attempts", zap.Error(err))
+	}
+}
+
+func (s *passcodeProtectionService) registerFailure(ctx context.Context, key string, lockoutAfter int, subject ...zap.Field) {
+	failures, err := s.attemptRepo.RegisterFailure(ctx, key, s.policy.Window)
+	if err != nil {
+		s.log.Error("failed to register passcode failure", zap.Error(err))
+		return
+	}
+	if lockoutAfter <= 0 || failures < lockoutAfter {
+		return
+	}
+
+	if err := s.attemptRepo.Lock(ctx, key, s.policy.LockoutDuration); err != nil {
+		s.log.Error("failed to lock passcode attempts", zap.Error(err))
+		return
+	}
+
+	s.log.Warn("session passcode locked out after repeated failures",
+		append(subject,
+			zap.Int("failures", failures),
+			zap.Duration("duration", s.policy.LockoutDuration),
+		)...,
+	)
+}
+
+func (s *passcodeProtectionService) keys(sessionID, userID, clientIP string) []string {
+	keys := []string{passcodeUserKey(sessionID, userID)}
+	if key := passcodeIPKey(clientIP); key != "" {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func passcodeUserKey(sessionID, userID string) string {
+	return "passcode:" + sessionID + ":" + userID
+}
+
+func passcodeIPKey(clientIP string) string {
+	if clientIP == "" {
+		return ""
+	}
+	return "passcode_ip:" + clientIP
+}
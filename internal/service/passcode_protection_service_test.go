@@ -0,0 +1,90 @@
+package service
+
+import (
+	"backend_go/internal/repository"
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestPasscodeProtection(t *testing.T) {
+	policy := PasscodeProtectionPolicy{
+		LockoutAfter:    3,
+		IPLockoutAfter:  5,
+		LockoutDuration: time.Minute,
+		Window:          time.Minute,
+	}
+
+	tests := []struct {
+		name string
+		// failures - неудачные попытки: сессия, пользователь, IP
+		failures  [][3]string
+		check     [3]string
+		wantLock  bool
+		resetUser bool
+	}{
+		{
+			name:     "below user threshold",
+			failures: [][3]string{{"s1", "u1", "ip1"}, {"s1", "u1", "ip1"}},
+			check:    [3]string{"s1", "u1", "ip1"},
+		},
+		{
+			name:     "user locked in session",
+			failures: [][3]string{{"s1", "u1", "ip1"}, {"s1", "u1", "ip1"}, {"s1", "u1", "ip1"}},
+			check:    [3]string{"s1", "u1", "ip2"},
+			wantLock: true,
+		},
+		{
+			name:     "user lock does not affect other sessions",
+			failures: [][3]string{{"s1", "u1", "ip1"}, {"s1", "u1", "ip1"}, {"s1", "u1", "ip1"}},
+			check:    [3]string{"s2", "u1", "ip2"},
+		},
+		{
+			name: "ip locked across guests and sessions",
+			failures: [][3]string{
+				{"s1", "g1", "ip1"}, {"s1", "g2", "ip1"}, {"s1", "g3", "ip1"}, {"s2", "g4", "ip1"}, {"s2", "g5", "ip1"},
+			},
+			check:    [3]string{"s3", "g6", "ip1"},
+			wantLock: true,
+		},
+		{
+			name:      "success resets user counter",
+			failures:  [][3]string{{"s1", "u1", "ip1"}, {"s1", "u1", "ip1"}},
+			resetUser: true,
+			check:     [3]string{"s1", "u1", "ip1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			protection := NewPasscodeProtectionService(repository.NewLoginAttemptMemoryRepo(zap.NewNop()), policy, zap.NewNop())
+
+			for _, f := range tt.failures {
+				protection.RegisterFailure(ctx, f[0], f[1], f[2])
+			}
+			if tt.resetUser {
+				protection.RegisterSuccess(ctx, "s1", "u1")
+				protection.RegisterFailure(ctx, "s1", "u1", "ip1")
+			}
+
+			err := protection.Check(ctx, tt.check[0], tt.check[1], tt.check[2])
+			if !tt.wantLock {
+				if err != nil {
+					t.Fatalf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var serviceErr *Error
+			if !errors.As(err, &serviceErr) || serviceErr.Code != ErrTooManyPasscodeAttempts.Code {
+				t.Fatalf("Check() error = %v, want %v", err, ErrTooManyPasscodeAttempts)
+			}
+			if serviceErr.RetryAfter <= 0 || serviceErr.RetryAfter > policy.LockoutDuration {
+				t.Errorf("RetryAfter = %v, want within (0, %v]", serviceErr.RetryAfter, policy.LockoutDuration)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"backend_go/internal/infrastructure/mailer"
+	"backend_go/internal/repository"
+	"backend_go/pkg/hash"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	passwordResetTokenSize = 32
+	// passwordResetMailTimeout - таймаут фоновой отправки письма со ссылкой сброса
+	passwordResetMailTimeout = 30 * time.Second
+)
+
+type passwordResetService struct {
+	userRepo        repository.UserRepository
+	resetRepo       repository.PasswordResetRepository
+	passwordService PasswordService
+	mailer          mailer.Mailer
+	baseURL         string
+	tokenTTL        time.Duration
+	log             *zap.Logger
+}
+
+func NewPasswordResetService(
+	userRepo repository.UserRepository,
+	resetRepo repository.PasswordResetRepository,
+	passwordService PasswordService,
+	mailer mailer.Mailer,
+	baseURL string,
+	tokenTTL time.Duration,
+	log *zap.Logger,
+) *passwordResetService {
+	return &passwordResetService{
+		userRepo:        userRepo,
+		resetRepo:       resetRepo,
+		passwordService: passwordService,
+		mailer:          mailer,
+		baseURL:         strings.TrimSuffix(baseURL, "/"),
+		tokenTTL:        tokenTTL,
+		log:             log,
+	}
+}
+
+// ForgotPassword создаёт одноразовый токен сброса и отправляет его на email.
+// Результат не зависит от того, существует ли пользователь: письмо отправляется в фоне,
+// чтобы по времени ответа тоже нельзя было это определить.
+func (s *passwordResetService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.log.Info("password reset requested for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !user.IsActive || user.IsGuest {
+		s.log.Info("password reset requested for inactive or guest user", zap.String("user_id", user.ID.String()))
+		return nil
+	}
+
+	token, err := hash.GenerateToken(passwordResetTokenSize)
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	if err := s.resetRepo.Create(ctx, user.ID, hash.HashToken(token), time.Now().Add(s.tokenTTL)); err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Восстановление пароля",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы задать новый пароль, перейдите по ссылке:\n%s\n\n"+
+				"Ссылка действительна %d мин. Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.",
+			user.Name, s.baseURL+"/reset-password?token="+url.QueryEscape(token), int(s.tokenTTL.Minutes()),
+		),
+	}
+	userID := user.ID.String()
+
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), passwordResetMailTimeout)
+		defer cancel()
+
+		if err := s.mailer.Send(sendCtx, msg); err != nil {
+			s.log.Error("failed to send password reset email", zap.String("user_id", userID), zap.Error(err))
+			return
+		}
+		s.log.Info("password reset email sent", zap.String("user_id", userID))
+	}()
+
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по токену и отзывает все выданные пользователю JWT
+func (s *passwordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	// Пароль проверяем до погашения токена, чтобы слабый пароль не сжигал ссылку
+	if err := s.passwordService.Validate("new_password", newPassword); err != nil {
+		return err
+	}
+
+	userID, err := s.resetRepo.Consume(ctx, hash.HashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwordService.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.RevokeTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.log.Info("password reset completed", zap.String("user_id", userID.String()))
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"backend_go/pkg/hash"
+	"backend_go/pkg/password"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+var passwordViolationMessages = map[string]string{
+	password.ViolationTooShort:      "password is too short",
+	password.ViolationTooLong:       "password is too long",
+	password.ViolationMissingUpper:  "password must contain an uppercase letter",
+	password.ViolationMissingLower:  "password must contain a lowercase letter",
+	password.ViolationMissingDigit:  "password must contain a digit",
+	password.ViolationMissingSymbol: "password must contain a special character",
+}
+
+type passwordService struct {
+	policy   password.Policy
+	breached *password.BreachedList
+	hasher   hash.Hasher
+	log      *zap.Logger
+}
+
+// NewPasswordService создаёт сервис паролей. breached может быть nil - тогда проверка утечек отключена.
+func NewPasswordService(
+	policy password.Policy,
+	breached *password.BreachedList,
+	hasher hash.Hasher,
+	log *zap.Logger,
+) *passwordService {
+	return &passwordService{
+		policy:   policy,
+		breached: breached,
+		hasher:   hasher,
+		log:      log,
+	}
+}
+
+// Validate проверяет новый пароль по политике и списку утечек.
+// field - имя поля запроса, к которому относятся ошибки.
+func (s *passwordService) Validate(field, plain string) error {
+	violations := s.policy.Validate(plain)
+	if len(violations) > 0 {
+		fields := make([]FieldError, 0, len(violations))
+		for _, violation := range violations {
+			fields = append(fields, FieldError{
+				Field:   field,
+				Code:    violation,
+				Message: passwordViolationMessages[violation],
+			})
+		}
+		return ErrWeakPassword.WithFields(fields...)
+	}
+
+	if s.breached.Contains(plain) {
+		s.log.Info("breached password rejected")
+		return ErrBreachedPassword.WithFields(FieldError{
+			Field:   field,
+			Code:    ErrBreachedPassword.Code,
+			Message: ErrBreachedPassword.Message,
+		})
+	}
+
+	return nil
+}
+
+func (s *passwordService) Hash(plain string) (string, error) {
+	hashed, err := s.hasher.Hash(plain)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return hashed, nil
+}
+
+func (s *passwordService) Check(plain, hashedPassword string) error {
+	return s.hasher.Check(plain, hashedPassword)
+}
+
+// NeedsRehash возвращает true, если хеш создан устаревшим алгоритмом или с устаревшими параметрами
+func (s *passwordService) NeedsRehash(hashedPassword string) bool {
+	return s.hasher.NeedsRehash(hashedPassword)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"backend_go/internal/model/entitymodel"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+const defaultSessionPageSize = 20
+
+// sessionCursor - содержимое курсора. Сортировка сохраняется в курсоре, чтобы курсор
+// от одной сортировки нельзя было применить к другой.
+type sessionCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// defaultSessionOrder: по датам новые сверху, по названию - по алфавиту
+func defaultSessionOrder(sort string) string {
+	if sort == entitymodel.SessionSortName {
+		return "asc"
+	}
+	return "desc"
+}
+
+func encodeSessionCursor(session *entitymodel.Session, filter *entitymodel.SessionFilter) string {
+	cursor := sessionCursor{Sort: filter.Sort, Desc: filter.Desc, ID: session.ID}
+	switch filter.Sort {
+	case entitymodel.SessionSortName:
+		cursor.Value = session.Name
+	case entitymodel.SessionSortLastActivity:
+		if session.LastActivityAt != nil {
+			cursor.Value = session.LastActivityAt.UTC().Format(time.RFC3339Nano)
+		}
+	default:
+		if session.CreatedAt != nil {
+			cursor.Value = session.CreatedAt.UTC().Format(time.RFC3339Nano)
+		}
+	}
+
+	// Маршалинг структуры из строк и bool не может завершиться ошибкой
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSessionCursor(value string, filter *entitymodel.SessionFilter) (*entitymodel.SessionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor sessionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != filter.Sort || cursor.Desc != filter.Desc || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != entitymodel.SessionSortName {
+		if _, err := time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return &entitymodel.SessionCursor{Value: cursor.Value, ID: cursor.ID}, nil
+}
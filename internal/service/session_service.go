@@ -1,35 +1,966 @@
 package service
 
 import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/converter"
 	"backend_go/internal/model/entitymodel"
 	"backend_go/internal/repository"
+	"backend_go/pkg/hash"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"go.uber.org/zap"
+	"strings"
 )
 
+// joinCodeAttempts - сколько раз генерируется новый код входа, если случайный уже занят
+const joinCodeAttempts = 5
+
 type sessionService struct {
-	sessionRepo repository.SessionRepository
-	log         *zap.Logger
+	sessionRepo          repository.SessionRepository
+	participantRepo      repository.SessionParticipantRepository
+	eventRepo            repository.SessionEventRepository
+	banRepo              repository.SessionBanRepository
+	storyRepo            repository.SessionStoryRepository
+	templateRepo         repository.SessionTemplateRepository
+	passcodeHasher       hash.Hasher
+	passcodeProtection   PasscodeProtectionService
+	requireVerifiedEmail bool
+	log                  *zap.Logger
 }
 
 func NewSessionService(
 	sessionRepo repository.SessionRepository,
+	participantRepo repository.SessionParticipantRepository,
+	eventRepo repository.SessionEventRepository,
+	banRepo repository.SessionBanRepository,
+	storyRepo repository.SessionStoryRepository,
+	templateRepo repository.SessionTemplateRepository,
+	passcodeHasher hash.Hasher,
+	passcodeProtection PasscodeProtectionService,
+	requireVerifiedEmail bool,
 	log *zap.Logger,
 ) *sessionService {
 	return &sessionService{
-		sessionRepo: sessionRepo,
-		log:         log,
+		sessionRepo:          sessionRepo,
+		participantRepo:      participantRepo,
+		eventRepo:            eventRepo,
+		banRepo:              banRepo,
+		storyRepo:            storyRepo,
+		templateRepo:         templateRepo,
+		passcodeHasher:       passcodeHasher,
+		passcodeProtection:   passcodeProtection,
+		requireVerifiedEmail: requireVerifiedEmail,
+		log:                  log,
 	}
 }
 
+// GetUserSession возвращает страницу сессий пользователя: созданных им и тех, где он участник.
+// По умолчанию показываются активные сессии, новые сверху.
 func (s *sessionService) GetUserSession(
 	ctx context.Context,
 	userId string,
-) ([]*entitymodel.Session, error) {
-	sessions, err := s.sessionRepo.GetByCreator(ctx, userId)
+	query *apimodel.SessionListQuery,
+) (*apimodel.SessionPage, error) {
+	filter := &entitymodel.SessionFilter{
+		UserID:      userId,
+		Search:      strings.TrimSpace(query.Search),
+		DeckType:    query.DeckType,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		Status:      stringOrDefault(query.Status, entitymodel.SessionStatusActive),
+		Role:        stringOrDefault(query.Role, entitymodel.SessionListRoleAll),
+		Sort:        stringOrDefault(query.Sort, entitymodel.SessionSortCreatedAt),
+		Limit:       defaultSessionPageSize,
+	}
+	filter.Desc = stringOrDefault(query.Order, defaultSessionOrder(filter.Sort)) == "desc"
+	if query.Limit > 0 {
+		filter.Limit = query.Limit
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeSessionCursor(query.Cursor, filter)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	total, err := s.sessionRepo.Count(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return sessions, nil
+	// Берём на одну запись больше, чтобы понять, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+	sessions, err := s.sessionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &apimodel.SessionPage{
+		Items:      make([]*apimodel.Session, 0, len(sessions)),
+		TotalCount: total,
+	}
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
+		next := encodeSessionCursor(sessions[limit-1], filter)
+		page.NextCursor = &next
+	}
+	for _, session := range sessions {
+		page.Items = append(page.Items, converter.SessionEntityToAPI(session))
+	}
+
+	return page, nil
+}
+
+// Archive убирает сессию из списка по умолчанию. Работать с архивной сессией по-прежнему можно.
+func (s *sessionService) Archive(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error) {
+	return s.setArchived(ctx, actor, true, entitymodel.SessionEventArchived)
+}
+
+func (s *sessionService) Unarchive(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error) {
+	return s.setArchived(ctx, actor, false, entitymodel.SessionEventUnarchived)
+}
+
+func (s *sessionService) setArchived(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	archived bool,
+	eventType string,
+) (*entitymodel.Session, error) {
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, eventType, "")
+	err := s.sessionRepo.SetArchived(ctx, actor.SessionID, archived, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session archive state changed",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+		zap.Bool("archived", archived),
+	)
+	return s.GetSession(ctx, actor.SessionID)
+}
+
+// CreateSession создаёт сессию. Если указан шаблон, из него берутся колода, флаги, таймер
+// и задачи, не заданные в запросе явно.
+func (s *sessionService) CreateSession(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.SessionCreate,
+) (*entitymodel.Session, error) {
+	if err := s.checkCanCreate(user); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrSessionNameEmpty
+	}
+
+	session := &entitymodel.Session{
+		Name:         name,
+		DeckType:     req.DeckType,
+		AllowEmoji:   true,
+		AutoReveal:   true,
+		TimerSeconds: req.TimerSeconds,
+	}
+	stories := req.Stories
+
+	if req.TemplateID != nil {
+		template, err := s.templateRepo.Get(ctx, user.ID, *req.TemplateID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSessionTemplateNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if session.DeckType == "" {
+			session.DeckType = template.DeckType
+		}
+		session.AllowEmoji = template.AllowEmoji
+		session.AutoReveal = template.AutoReveal
+		if session.TimerSeconds == nil {
+			session.TimerSeconds = template.TimerSeconds
+		}
+		if stories == nil {
+			stories = template.Stories
+		}
+	}
+
+	if session.DeckType == "" {
+		return nil, ErrSessionDeckTypeEmpty
+	}
+	session.AllowEmoji = boolOrDefault(req.AllowEmoji, session.AllowEmoji)
+	session.AutoReveal = boolOrDefault(req.AutoReveal, session.AutoReveal)
+	session.AllowedEmailDomains = entitymodel.NormalizeEmailDomains(req.AllowedEmailDomains)
+
+	passcodeHash, err := s.hashPasscode(req.Passcode)
+	if err != nil {
+		return nil, err
+	}
+	session.PasscodeHash = passcodeHash
+
+	return s.create(ctx, user, session, normalizeStoryTitles(stories))
+}
+
+// CloneSession создаёт сессию с настройками и ограничениями входа исходной.
+// Участники, голоса и история не переносятся; задачи - только неоценённые и только по запросу.
+func (s *sessionService) CloneSession(
+	ctx context.Context,
+	user *entitymodel.User,
+	actor *entitymodel.SessionParticipant,
+	req *apimodel.SessionClone,
+) (*entitymodel.Session, error) {
+	if err := s.checkCanCreate(user); err != nil {
+		return nil, err
+	}
+
+	source, err := s.GetSession(ctx, actor.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := source.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	if name == "" {
+		return nil, ErrSessionNameEmpty
+	}
+
+	stories := make([]string, 0)
+	if req.CarryOverStories {
+		sourceStories, err := s.storyRepo.ListBySession(ctx, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, story := range sourceStories {
+			if !story.IsEstimated() {
+				stories = append(stories, story.Title)
+			}
+		}
+	}
+
+	session := &entitymodel.Session{
+		Name:                name,
+		DeckType:            source.DeckType,
+		AllowEmoji:          source.AllowEmoji,
+		AutoReveal:          source.AutoReveal,
+		TimerSeconds:        source.TimerSeconds,
+		PasscodeHash:        source.PasscodeHash,
+		AllowedEmailDomains: source.AllowedEmailDomains,
+	}
+
+	created, err := s.create(ctx, user, session, stories)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session cloned",
+		zap.String("source_session_id", source.ID),
+		zap.String("session_id", created.ID),
+		zap.Int("stories", len(stories)),
+	)
+	return created, nil
+}
+
+func (s *sessionService) GetSession(ctx context.Context, id string) (*entitymodel.Session, error) {
+	session, err := s.sessionRepo.GetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *sessionService) GetParticipant(
+	ctx context.Context,
+	sessionID, userID string,
+) (*entitymodel.SessionParticipant, error) {
+	participant, err := s.participantRepo.Get(ctx, sessionID, userID)
+	if err == nil {
+		return participant, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// Отличаем несуществующую сессию от чужой, а удалённого участника - от постороннего
+	if _, err := s.GetSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBan(ctx, sessionID, userID); err != nil {
+		return nil, err
+	}
+
+	kicked, err := s.eventRepo.HasEvent(ctx, sessionID, userID, entitymodel.SessionEventParticipantKicked)
+	if err != nil {
+		return nil, err
+	}
+	if kicked {
+		return nil, ErrKickedFromSession
+	}
+
+	return nil, ErrNotSessionParticipant
+}
+
+// Join добавляет пользователя в сессию голосующим или наблюдателем.
+// Повторный вход не меняет уже назначенную роль и не требует кода-пароля.
+func (s *sessionService) Join(
+	ctx context.Context,
+	user *entitymodel.User,
+	sessionID string,
+	req *apimodel.SessionJoin,
+	clientIP string,
+) (*entitymodel.SessionParticipant, error) {
+	return s.join(ctx, user, sessionID, req.AsObserver, func(session *entitymodel.Session) error {
+		return s.checkAccess(ctx, user, session, req.Passcode, clientIP)
+	})
+}
+
+// JoinByInvite - вход по приглашению: ссылка заменяет код-пароль, но ограничение по домену email действует
+func (s *sessionService) JoinByInvite(
+	ctx context.Context,
+	user *entitymodel.User,
+	sessionID string,
+	asObserver bool,
+) (*entitymodel.SessionParticipant, error) {
+	return s.join(ctx, user, sessionID, asObserver, func(session *entitymodel.Session) error {
+		return checkEmailDomain(user, session)
+	})
+}
+
+// JoinByCode - вход по короткому коду сессии вместо её id
+func (s *sessionService) JoinByCode(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.SessionJoinByCode,
+	clientIP string,
+) (*entitymodel.SessionParticipant, error) {
+	session, err := s.sessionRepo.GetByJoinCode(ctx, entitymodel.NormalizeJoinCode(req.Code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrJoinCodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Join(ctx, user, session.ID, &apimodel.SessionJoin{AsObserver: req.AsObserver, Passcode: req.Passcode}, clientIP)
+}
+
+// UpdateAccess меняет код-пароль и список доменов. Уже вошедших участников изменения не затрагивают.
+func (s *sessionService) UpdateAccess(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	req *apimodel.SessionAccessUpdate,
+) (*entitymodel.Session, error) {
+	session, err := s.GetSession(ctx, actor.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.RemovePasscode:
+		session.PasscodeHash = nil
+	case req.Passcode != nil:
+		session.PasscodeHash, err = s.hashPasscode(req.Passcode)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.AllowedEmailDomains != nil {
+		session.AllowedEmailDomains = entitymodel.NormalizeEmailDomains(*req.AllowedEmailDomains)
+	}
+
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, entitymodel.SessionEventAccessChanged, "")
+	err = s.sessionRepo.SetAccess(ctx, actor.SessionID, session.PasscodeHash, session.AllowedEmailDomains, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session access changed",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+		zap.Bool("has_passcode", session.HasPasscode()),
+		zap.Int("allowed_email_domains", len(session.AllowedEmailDomains)),
+	)
+	return session, nil
+}
+
+// RegenerateJoinCode выдаёт сессии новый код входа, например если старый попал к посторонним
+func (s *sessionService) RegenerateJoinCode(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error) {
+	session, err := s.GetSession(ctx, actor.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = withJoinCode(func(code string) error {
+		session.JoinCode = code
+		return s.sessionRepo.SetJoinCode(ctx, actor.SessionID, code)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session join code regenerated",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+	)
+	return session, nil
+}
+
+func (s *sessionService) ListParticipants(ctx context.Context, sessionID string) ([]*entitymodel.SessionParticipant, error) {
+	return s.participantRepo.ListBySession(ctx, sessionID)
+}
+
+// SetParticipantRole переключает участника между голосующим и наблюдателем.
+// Роли ведущего и соведущих меняются отдельными действиями.
+func (s *sessionService) SetParticipantRole(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	userID, role string,
+) (*entitymodel.SessionParticipant, error) {
+	if role != entitymodel.SessionRoleVoter && role != entitymodel.SessionRoleObserver {
+		return nil, ErrFacilitatorRoleChange
+	}
+
+	target, err := s.getTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.CanManage() {
+		return nil, ErrFacilitatorRoleChange
+	}
+	if target.Role == role {
+		return target, nil
+	}
+
+	return s.changeRoles(ctx, actor, target, entitymodel.SessionEventRoleChanged,
+		entitymodel.SessionRoleChange{UserID: target.UserID, From: target.Role, To: role},
+	)
+}
+
+// TransferFacilitator передаёт права ведущего другому участнику.
+// Бывший ведущий остаётся соведущим, чтобы не потерять управление сессией.
+func (s *sessionService) TransferFacilitator(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	userID string,
+) (*entitymodel.SessionParticipant, error) {
+	if actor.Role != entitymodel.SessionRoleFacilitator {
+		return nil, ErrInsufficientSessionRole
+	}
+
+	target, err := s.getTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сначала понижаем текущего ведущего: в сессии не может быть двух ведущих одновременно
+	return s.changeRoles(ctx, actor, target, entitymodel.SessionEventFacilitatorTransferred,
+		entitymodel.SessionRoleChange{
+			UserID: actor.UserID,
+			From:   entitymodel.SessionRoleFacilitator,
+			To:     entitymodel.SessionRoleCoFacilitator,
+		},
+		entitymodel.SessionRoleChange{
+			UserID: target.UserID,
+			From:   target.Role,
+			To:     entitymodel.SessionRoleFacilitator,
+		},
+	)
+}
+
+func (s *sessionService) AddCoFacilitator(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	userID string,
+) (*entitymodel.SessionParticipant, error) {
+	if actor.Role != entitymodel.SessionRoleFacilitator {
+		return nil, ErrInsufficientSessionRole
+	}
+
+	target, err := s.getTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.CanManage() {
+		return nil, ErrAlreadyCoFacilitator
+	}
+
+	return s.changeRoles(ctx, actor, target, entitymodel.SessionEventCoFacilitatorAdded,
+		entitymodel.SessionRoleChange{
+			UserID: target.UserID,
+			From:   target.Role,
+			To:     entitymodel.SessionRoleCoFacilitator,
+		},
+	)
+}
+
+// RemoveCoFacilitator снимает права соведущего, участник остаётся голосующим
+func (s *sessionService) RemoveCoFacilitator(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	userID string,
+) (*entitymodel.SessionParticipant, error) {
+	if actor.Role != entitymodel.SessionRoleFacilitator {
+		return nil, ErrInsufficientSessionRole
+	}
+
+	target, err := s.getTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Role != entitymodel.SessionRoleCoFacilitator {
+		return nil, ErrNotCoFacilitator
+	}
+
+	return s.changeRoles(ctx, actor, target, entitymodel.SessionEventCoFacilitatorRemoved,
+		entitymodel.SessionRoleChange{
+			UserID: target.UserID,
+			From:   entitymodel.SessionRoleCoFacilitator,
+			To:     entitymodel.SessionRoleVoter,
+		},
+	)
+}
+
+// RevealCards открывает карты текущего раунда
+func (s *sessionService) RevealCards(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error) {
+	session, err := s.GetSession(ctx, actor.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.CardsRevealed {
+		return nil, ErrCardsAlreadyRevealed
+	}
+
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, entitymodel.SessionEventCardsRevealed, "")
+	err = s.sessionRepo.SetCardsRevealed(ctx, actor.SessionID, true, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session.CardsRevealed = true
+	return session, nil
+}
+
+// ResetVotes начинает новый раунд: голоса удаляются, карты скрываются
+func (s *sessionService) ResetVotes(ctx context.Context, actor *entitymodel.SessionParticipant) (*entitymodel.Session, error) {
+	session, err := s.GetSession(ctx, actor.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, entitymodel.SessionEventVotesReset, "")
+	err = s.sessionRepo.ResetVotes(ctx, actor.SessionID, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session.CardsRevealed = false
+	return session, nil
+}
+
+func (s *sessionService) ListEvents(ctx context.Context, sessionID string) ([]*entitymodel.SessionEvent, error) {
+	return s.eventRepo.ListBySession(ctx, sessionID)
+}
+
+// Kick удаляет участника из сессии. Вернуться он может сам, через Join.
+// Пока он не вернулся, запросы к сессии получают ErrKickedFromSession.
+func (s *sessionService) Kick(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) error {
+	target, err := s.getTarget(ctx, actor, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkCanRemove(actor, target); err != nil {
+		return err
+	}
+
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, entitymodel.SessionEventParticipantKicked, userID)
+	err = s.participantRepo.Remove(ctx, actor.SessionID, userID, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrParticipantNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("participant kicked",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+		zap.String("target_id", userID),
+	)
+	return nil
+}
+
+// Ban удаляет участника и запрещает ему вход в сессию. Заблокировать можно
+// и того, кто сейчас не в сессии, например уже удалённого через Kick.
+func (s *sessionService) Ban(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	req *apimodel.SessionBanCreate,
+) (*entitymodel.SessionBan, error) {
+	target, err := s.getTarget(ctx, actor, req.UserID)
+	switch {
+	case err == nil:
+		if err := checkCanRemove(actor, target); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, ErrParticipantNotFound):
+	default:
+		return nil, err
+	}
+
+	ban := &entitymodel.SessionBan{
+		SessionID: actor.SessionID,
+		UserID:    req.UserID,
+		BannedBy:  &actor.UserID,
+		Reason:    req.Reason,
+	}
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, entitymodel.SessionEventParticipantBanned, req.UserID)
+
+	saved, err := s.banRepo.Ban(ctx, ban, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("participant banned",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+		zap.String("target_id", req.UserID),
+	)
+	return saved, nil
+}
+
+// Unban снимает блокировку; пользователь снова может войти через Join
+func (s *sessionService) Unban(ctx context.Context, actor *entitymodel.SessionParticipant, userID string) error {
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, entitymodel.SessionEventParticipantUnbanned, userID)
+
+	err := s.banRepo.Unban(ctx, actor.SessionID, userID, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSessionBanNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("participant unbanned",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+		zap.String("target_id", userID),
+	)
+	return nil
+}
+
+func (s *sessionService) ListBans(ctx context.Context, sessionID string) ([]*entitymodel.SessionBan, error) {
+	return s.banRepo.ListBySession(ctx, sessionID)
+}
+
+// join добавляет участника, если он ещё не в сессии; checkAccess проверяет ограничения входа
+func (s *sessionService) join(
+	ctx context.Context,
+	user *entitymodel.User,
+	sessionID string,
+	asObserver bool,
+	checkAccess func(session *entitymodel.Session) error,
+) (*entitymodel.SessionParticipant, error) {
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.participantRepo.Get(ctx, sessionID, user.ID.String())
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if err := s.checkBan(ctx, sessionID, user.ID.String()); err != nil {
+		return nil, err
+	}
+	if err := checkAccess(session); err != nil {
+		s.log.Info("session join denied",
+			zap.String("session_id", sessionID),
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	role := entitymodel.SessionRoleVoter
+	if asObserver {
+		role = entitymodel.SessionRoleObserver
+	}
+
+	participant, err := s.participantRepo.Add(ctx, sessionID, user.ID.String(), role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBannedFromSession
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("user joined session",
+		zap.String("session_id", sessionID),
+		zap.String("user_id", user.ID.String()),
+		zap.String("role", participant.Role),
+	)
+	return participant, nil
+}
+
+// checkAccess проверяет домен email и код-пароль. Неверные коды-пароли считаются
+// для пользователя в сессии и для IP, после блокировки код не проверяется.
+func (s *sessionService) checkAccess(
+	ctx context.Context,
+	user *entitymodel.User,
+	session *entitymodel.Session,
+	passcode *string,
+	clientIP string,
+) error {
+	if err := checkEmailDomain(user, session); err != nil {
+		return err
+	}
+	if !session.HasPasscode() {
+		return nil
+	}
+	if passcode == nil || *passcode == "" {
+		return ErrSessionPasscodeRequired
+	}
+
+	userID := user.ID.String()
+	if err := s.passcodeProtection.Check(ctx, session.ID, userID, clientIP); err != nil {
+		return err
+	}
+
+	err := s.passcodeHasher.Check(*passcode, *session.PasscodeHash)
+	if errors.Is(err, hash.ErrPasswordMismatch) {
+		s.passcodeProtection.RegisterFailure(ctx, session.ID, userID, clientIP)
+		return ErrInvalidSessionPasscode
+	}
+	if err != nil {
+		return err
+	}
+
+	s.passcodeProtection.RegisterSuccess(ctx, session.ID, userID)
+	return nil
+}
+
+func (s *sessionService) hashPasscode(passcode *string) (*string, error) {
+	if passcode == nil {
+		return nil, nil
+	}
+
+	hashed, err := s.passcodeHasher.Hash(*passcode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash session passcode: %w", err)
+	}
+	return &hashed, nil
+}
+
+// checkEmailDomain: в сессию с ограничением по домену пускаем только подтверждённый email из списка
+func checkEmailDomain(user *entitymodel.User, session *entitymodel.Session) error {
+	if !session.IsRestrictedByDomain() {
+		return nil
+	}
+	if user.Email == "" {
+		return ErrEmailDomainNotAllowed
+	}
+	if !user.IsVerified {
+		return ErrSessionEmailNotVerified
+	}
+	if !session.IsEmailDomainAllowed(user.Email) {
+		return ErrEmailDomainNotAllowed
+	}
+	return nil
+}
+
+func (s *sessionService) checkBan(ctx context.Context, sessionID, userID string) error {
+	banned, err := s.banRepo.IsBanned(ctx, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return ErrBannedFromSession
+	}
+	return nil
+}
+
+// checkCanRemove: ведущего удалить нельзя, соведущего может удалить только ведущий
+func checkCanRemove(actor, target *entitymodel.SessionParticipant) error {
+	switch target.Role {
+	case entitymodel.SessionRoleFacilitator:
+		return ErrCannotRemoveFacilitator
+	case entitymodel.SessionRoleCoFacilitator:
+		if actor.Role != entitymodel.SessionRoleFacilitator {
+			return ErrInsufficientSessionRole
+		}
+	}
+	return nil
+}
+
+// getTarget находит участника, над которым выполняется действие. Действовать над собой нельзя.
+func (s *sessionService) getTarget(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	userID string,
+) (*entitymodel.SessionParticipant, error) {
+	if userID == actor.UserID {
+		return nil, ErrCannotTargetSelf
+	}
+
+	target, err := s.participantRepo.Get(ctx, actor.SessionID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrParticipantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// changeRoles применяет смены ролей вместе с записью в историю и возвращает обновлённого target
+func (s *sessionService) changeRoles(
+	ctx context.Context,
+	actor, target *entitymodel.SessionParticipant,
+	eventType string,
+	changes ...entitymodel.SessionRoleChange,
+) (*entitymodel.SessionParticipant, error) {
+	event := entitymodel.NewSessionEvent(actor.SessionID, actor.UserID, eventType, target.UserID)
+
+	err := s.participantRepo.ChangeRoles(ctx, actor.SessionID, changes, event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionRoleConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session roles changed",
+		zap.String("session_id", actor.SessionID),
+		zap.String("actor_id", actor.UserID),
+		zap.String("target_id", target.UserID),
+		zap.String("event", eventType),
+	)
+
+	for _, change := range changes {
+		if change.UserID == target.UserID {
+			target.Role = change.To
+		}
+	}
+	return target, nil
+}
+
+func (s *sessionService) checkCanCreate(user *entitymodel.User) error {
+	if s.requireVerifiedEmail && !user.IsVerified {
+		s.log.Info("unverified user tried to create session", zap.String("user_id", user.ID.String()))
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// create сохраняет сессию от имени user со свободным кодом входа
+func (s *sessionService) create(
+	ctx context.Context,
+	user *entitymodel.User,
+	session *entitymodel.Session,
+	stories []string,
+) (*entitymodel.Session, error) {
+	session.CreatorID = user.ID.String()
+	session.CreatorName = user.Name
+	session.CreatedVia = entitymodel.SessionCreatedViaUser
+	if user.IsGuest {
+		session.CreatedVia = entitymodel.SessionCreatedViaGuest
+	}
+	if session.AllowedEmailDomains == nil {
+		session.AllowedEmailDomains = make([]string, 0)
+	}
+
+	var createdSession *entitymodel.Session
+	err := withJoinCode(func(code string) error {
+		session.JoinCode = code
+		var err error
+		createdSession, err = s.sessionRepo.Create(ctx, session, stories)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session created", zap.String("session_id", createdSession.ID), zap.String("user_id", user.ID.String()))
+	return createdSession, nil
+}
+
+// withJoinCode вызывает save со случайным кодом входа и повторяет попытку, пока код занят
+func withJoinCode(save func(code string) error) error {
+	for attempt := 0; attempt < joinCodeAttempts; attempt++ {
+		code, err := hash.GenerateCode(entitymodel.JoinCodeAlphabet, entitymodel.JoinCodeLength)
+		if err != nil {
+			return fmt.Errorf("failed to generate join code: %w", err)
+		}
+
+		err = save(code)
+		if !errors.Is(err, repository.ErrJoinCodeTaken) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to find a free join code in %d attempts", joinCodeAttempts)
+}
+
+// normalizeStoryTitles убирает лишние пробелы и всегда возвращает не-nil срез
+func normalizeStoryTitles(titles []string) []string {
+	normalized := make([]string, 0, len(titles))
+	for _, title := range titles {
+		if title = strings.TrimSpace(title); title != "" {
+			normalized = append(normalized, title)
+		}
+	}
+	return normalized
+}
+
+func stringOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func boolOrDefault(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// storyPositionAttempts - сколько раз повторяется добавление задачи, если её позицию одновременно заняли
+const storyPositionAttempts = 3
+
+type storyService struct {
+	sessionRepo repository.SessionRepository
+	storyRepo   repository.SessionStoryRepository
+	log         *zap.Logger
+}
+
+func NewStoryService(
+	sessionRepo repository.SessionRepository,
+	storyRepo repository.SessionStoryRepository,
+	log *zap.Logger,
+) *storyService {
+	return &storyService{
+		sessionRepo: sessionRepo,
+		storyRepo:   storyRepo,
+		log:         log,
+	}
+}
+
+func (s *storyService) List(ctx context.Context, sessionID string) ([]*entitymodel.SessionStory, error) {
+	return s.storyRepo.ListBySession(ctx, sessionID)
+}
+
+// Create добавляет задачу в конец списка сессии
+func (s *storyService) Create(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	req *apimodel.SessionStoryCreate,
+) (*entitymodel.SessionStory, error) {
+	title := strings.TrimSpace(req.Title)
+
+	var story *entitymodel.SessionStory
+	var err error
+	for attempt := 0; attempt < storyPositionAttempts; attempt++ {
+		story, err = s.storyRepo.Create(ctx, actor.SessionID, title)
+		if !errors.Is(err, repository.ErrStoryPositionTaken) {
+			break
+		}
+	}
+	if errors.Is(err, repository.ErrStoryPositionTaken) {
+		return nil, ErrSessionStoriesChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session story created",
+		zap.String("session_id", actor.SessionID),
+		zap.String("story_id", story.ID),
+		zap.String("actor_id", actor.UserID),
+	)
+	return story, nil
+}
+
+// SetEstimate сохраняет итоговую оценку задачи; оценка должна быть картой колоды сессии
+func (s *storyService) SetEstimate(
+	ctx context.Context,
+	actor *entitymodel.SessionParticipant,
+	storyID, estimate string,
+) (*entitymodel.SessionStory, error) {
+	session, err := s.sessionRepo.GetByID(ctx, actor.SessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !entitymodel.IsValidVoteValue(session.DeckType, estimate) {
+		return nil, ErrInvalidEstimate
+	}
+
+	story, err := s.storyRepo.SetEstimate(ctx, actor.SessionID, storyID, estimate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionStoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return story, nil
+}
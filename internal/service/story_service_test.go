@@ -0,0 +1,58 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"testing"
+)
+
+// racingStoryRepo отдаёт ErrStoryPositionTaken первые taken вызовов Create
+type racingStoryRepo struct {
+	repository.SessionStoryRepository
+	taken int
+	calls int
+}
+
+func (r *racingStoryRepo) Create(_ context.Context, sessionID, title string) (*entitymodel.SessionStory, error) {
+	r.calls++
+	if r.calls <= r.taken {
+		return nil, repository.ErrStoryPositionTaken
+	}
+	return &entitymodel.SessionStory{ID: "story", SessionID: sessionID, Title: title, Position: r.calls}, nil
+}
+
+func TestStoryServiceCreateRetriesTakenPosition(t *testing.T) {
+	tests := []struct {
+		name      string
+		taken     int
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "free position", taken: 0, wantCalls: 1},
+		{name: "position taken once", taken: 1, wantCalls: 2},
+		{name: "position taken on every attempt", taken: storyPositionAttempts, wantErr: ErrSessionStoriesChanged, wantCalls: storyPositionAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &racingStoryRepo{taken: tt.taken}
+			svc := NewStoryService(nil, repo, zap.NewNop())
+			actor := &entitymodel.SessionParticipant{SessionID: "session", UserID: "user"}
+
+			story, err := svc.Create(context.Background(), actor, &apimodel.SessionStoryCreate{Title: "  Login page "})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.calls != tt.wantCalls {
+				t.Errorf("Create() called repo %d times, want %d", repo.calls, tt.wantCalls)
+			}
+			if tt.wantErr == nil && story.Title != "Login page" {
+				t.Errorf("Create() title = %q, want %q", story.Title, "Login page")
+			}
+		})
+	}
+}
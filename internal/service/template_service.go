@@ -0,0 +1,115 @@
+package service
+
+import (
+	"backend_go/internal/model/apimodel"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"go.uber.org/zap"
+	"strings"
+)
+
+const maxSessionTemplatesPerUser = 50
+
+type templateService struct {
+	templateRepo repository.SessionTemplateRepository
+	log          *zap.Logger
+}
+
+func NewTemplateService(templateRepo repository.SessionTemplateRepository, log *zap.Logger) *templateService {
+	return &templateService{
+		templateRepo: templateRepo,
+		log:          log,
+	}
+}
+
+func (s *templateService) List(ctx context.Context, user *entitymodel.User) ([]*entitymodel.SessionTemplate, error) {
+	return s.templateRepo.ListByOwner(ctx, user.ID)
+}
+
+func (s *templateService) Create(
+	ctx context.Context,
+	user *entitymodel.User,
+	req *apimodel.SessionTemplateSave,
+) (*entitymodel.SessionTemplate, error) {
+	if user.IsGuest {
+		return nil, ErrGuestForbidden
+	}
+
+	count, err := s.templateRepo.CountByOwner(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSessionTemplatesPerUser {
+		return nil, ErrTooManySessionTemplates
+	}
+
+	template, err := newSessionTemplate(user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.templateRepo.Create(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("session template created", zap.String("template_id", created.ID), zap.String("user_id", user.ID.String()))
+	return created, nil
+}
+
+// Update заменяет шаблон целиком. Сессии, уже созданные из шаблона, не меняются.
+func (s *templateService) Update(
+	ctx context.Context,
+	user *entitymodel.User,
+	id string,
+	req *apimodel.SessionTemplateSave,
+) (*entitymodel.SessionTemplate, error) {
+	template, err := newSessionTemplate(user, req)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = id
+
+	updated, err := s.templateRepo.Update(ctx, template)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *templateService) Delete(ctx context.Context, user *entitymodel.User, id string) error {
+	err := s.templateRepo.Delete(ctx, user.ID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSessionTemplateNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("session template deleted", zap.String("template_id", id), zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+func newSessionTemplate(user *entitymodel.User, req *apimodel.SessionTemplateSave) (*entitymodel.SessionTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrSessionNameEmpty
+	}
+
+	return &entitymodel.SessionTemplate{
+		OwnerID:      user.ID,
+		Name:         name,
+		DeckType:     req.DeckType,
+		AllowEmoji:   boolOrDefault(req.AllowEmoji, true),
+		AutoReveal:   boolOrDefault(req.AutoReveal, true),
+		TimerSeconds: req.TimerSeconds,
+		Stories:      normalizeStoryTitles(req.Stories),
+	}, nil
+}
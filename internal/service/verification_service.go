@@ -0,0 +1,112 @@
+package service
+
+import (
+	"backend_go/internal/infrastructure/mailer"
+	"backend_go/internal/model/entitymodel"
+	"backend_go/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type verificationService struct {
+	userRepo   repository.UserRepository
+	jwtService JWTService
+	mailer     mailer.Mailer
+	baseURL    string
+	tokenTTL   time.Duration
+	log        *zap.Logger
+}
+
+func NewVerificationService(
+	userRepo repository.UserRepository,
+	jwtService JWTService,
+	mailer mailer.Mailer,
+	baseURL string,
+	tokenTTL time.Duration,
+	log *zap.Logger,
+) *verificationService {
+	return &verificationService{
+		userRepo:   userRepo,
+		jwtService: jwtService,
+		mailer:     mailer,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		tokenTTL:   tokenTTL,
+		log:        log,
+	}
+}
+
+// SendVerification отправляет пользователю письмо со ссылкой подтверждения email
+func (s *verificationService) SendVerification(ctx context.Context, user *entitymodel.User) error {
+	if user.IsVerified {
+		return ErrEmailAlreadyVerified
+	}
+	if user.Email == "" {
+		return ErrEmailRequired
+	}
+
+	token, err := s.jwtService.GeneratePurposeToken(PurposeEmailVerification, user.ID.String(), user.Email, s.tokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	link := s.baseURL + "/verify-email?token=" + url.QueryEscape(token)
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Подтверждение email",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы подтвердить email, перейдите по ссылке:\n%s\n\nСсылка действительна %d ч.",
+			user.Name, link, int(s.tokenTTL.Hours()),
+		),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("verification email sent", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// VerifyEmail проверяет токен и помечает email пользователя подтверждённым.
+// Токен привязан к email, поэтому после смены адреса старые ссылки перестают работать.
+func (s *verificationService) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := s.jwtService.ParsePurposeToken(token, PurposeEmailVerification)
+	if err != nil {
+		s.log.Info("failed to parse verification token", zap.Error(err))
+		return ErrInvalidVerificationToken
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Email != claims.Email {
+		return ErrInvalidVerificationToken
+	}
+	if user.IsVerified {
+		return nil
+	}
+
+	if err := s.userRepo.MarkVerified(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.log.Info("email verified", zap.String("user_id", user.ID.String()))
+	return nil
+}
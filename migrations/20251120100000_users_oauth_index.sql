@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Один OAuth-аккаунт может быть привязан только к одному пользователю
+CREATE UNIQUE INDEX ix_users_oauth ON public.users (oauth_provider, oauth_id) WHERE oauth_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.ix_users_oauth;
+-- +goose StatementEnd
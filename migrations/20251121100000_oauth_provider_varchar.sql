@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Набор провайдеров задаётся конфигурацией (OIDC), поэтому enum заменяется на строку с проверкой формата
+ALTER TABLE public.users ALTER COLUMN oauth_provider TYPE VARCHAR(32) USING oauth_provider::text;
+ALTER TABLE public.users
+    ADD CONSTRAINT ck_users_oauth_provider CHECK (oauth_provider ~ '^[A-Z][A-Z0-9_-]{0,31}$');
+DROP TYPE IF EXISTS public.oauthproviderenum;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Откат возможен только если в БД нет пользователей сторонних OIDC-провайдеров
+CREATE TYPE public.oauthproviderenum AS ENUM ('GOOGLE', 'YANDEX');
+ALTER TABLE public.users DROP CONSTRAINT IF EXISTS ck_users_oauth_provider;
+ALTER TABLE public.users ALTER COLUMN oauth_provider TYPE oauthproviderenum USING oauth_provider::oauthproviderenum;
+-- +goose StatementEnd
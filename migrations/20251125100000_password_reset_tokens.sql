@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Токены, выданные раньше этого момента, считаются отозванными
+ALTER TABLE public.users ADD COLUMN tokens_valid_after TIMESTAMP WITH TIME ZONE;
+
+-- Одноразовые токены сброса пароля (хранится только sha256 от токена)
+CREATE TABLE public.password_reset_tokens (
+                                              id         UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                              user_id    UUID NOT NULL REFERENCES public.users ON DELETE CASCADE,
+                                              token_hash VARCHAR NOT NULL,
+                                              expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                              used_at    TIMESTAMP WITH TIME ZONE,
+                                              created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+ALTER TABLE public.password_reset_tokens OWNER TO agile_poker_user;
+
+CREATE UNIQUE INDEX ix_password_reset_tokens_token_hash ON public.password_reset_tokens (token_hash);
+CREATE INDEX ix_password_reset_tokens_user_id ON public.password_reset_tokens (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.password_reset_tokens;
+ALTER TABLE public.users DROP COLUMN IF EXISTS tokens_valid_after;
+-- +goose StatementEnd
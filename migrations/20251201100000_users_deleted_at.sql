@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Удалённые пользователи анонимизируются, но строка остаётся, чтобы не ломать голоса и реакции
+ALTER TABLE public.users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.users DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
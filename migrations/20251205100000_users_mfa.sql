@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- TOTP: секрет появляется при начале подключения, mfa_enabled - после подтверждения кодом.
+-- mfa_last_step - номер последнего принятого интервала, чтобы код нельзя было использовать повторно.
+ALTER TABLE public.users ADD COLUMN mfa_secret VARCHAR;
+ALTER TABLE public.users ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE public.users ADD COLUMN mfa_last_step BIGINT;
+
+-- Одноразовые коды восстановления (хранится только sha256 от кода)
+CREATE TABLE public.mfa_recovery_codes (
+                                           id         UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                           user_id    UUID NOT NULL REFERENCES public.users ON DELETE CASCADE,
+                                           code_hash  VARCHAR NOT NULL,
+                                           used_at    TIMESTAMP WITH TIME ZONE,
+                                           created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+ALTER TABLE public.mfa_recovery_codes OWNER TO agile_poker_user;
+
+CREATE UNIQUE INDEX ix_mfa_recovery_codes_user_id_code_hash ON public.mfa_recovery_codes (user_id, code_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.mfa_recovery_codes;
+ALTER TABLE public.users DROP COLUMN IF EXISTS mfa_last_step;
+ALTER TABLE public.users DROP COLUMN IF EXISTS mfa_enabled;
+ALTER TABLE public.users DROP COLUMN IF EXISTS mfa_secret;
+-- +goose StatementEnd
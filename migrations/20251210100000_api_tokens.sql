@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Персональные токены доступа для ботов и интеграций (хранится только sha256 от токена)
+CREATE TABLE public.api_tokens (
+                                   id           UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                   user_id      UUID NOT NULL REFERENCES public.users ON DELETE CASCADE,
+                                   name         VARCHAR NOT NULL,
+                                   token_hash   VARCHAR NOT NULL,
+                                   token_prefix VARCHAR NOT NULL,
+                                   scopes       TEXT[] NOT NULL,
+                                   expires_at   TIMESTAMP WITH TIME ZONE,
+                                   last_used_at TIMESTAMP WITH TIME ZONE,
+                                   created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+ALTER TABLE public.api_tokens OWNER TO agile_poker_user;
+
+CREATE UNIQUE INDEX ix_api_tokens_token_hash ON public.api_tokens (token_hash);
+CREATE INDEX ix_api_tokens_user_id ON public.api_tokens (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.api_tokens;
+-- +goose StatementEnd
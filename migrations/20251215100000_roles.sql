@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Глобальная роль пользователя. Первого администратора назначают вручную:
+-- UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE public.users ADD COLUMN role VARCHAR NOT NULL DEFAULT 'user';
+UPDATE public.users SET role = 'guest' WHERE is_guest;
+ALTER TABLE public.users ADD CONSTRAINT ck_users_role CHECK (role IN ('admin', 'user', 'guest'));
+
+-- Участники сессий и их роли
+CREATE TABLE public.session_participants (
+                                             session_id UUID NOT NULL REFERENCES public.sessions ON DELETE CASCADE,
+                                             user_id    UUID NOT NULL REFERENCES public.users ON DELETE CASCADE,
+                                             role       VARCHAR NOT NULL,
+                                             joined_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+                                             PRIMARY KEY (session_id, user_id),
+                                             CONSTRAINT ck_session_participants_role CHECK (role IN ('facilitator', 'voter', 'observer'))
+);
+ALTER TABLE public.session_participants OWNER TO agile_poker_user;
+
+CREATE INDEX ix_session_participants_user_id ON public.session_participants (user_id);
+
+-- Создатели существующих сессий становятся их ведущими
+INSERT INTO public.session_participants (session_id, user_id, role, joined_at)
+SELECT s.id, s.creator_id, 'facilitator', s.created_at
+FROM public.sessions s
+JOIN public.users u ON u.id = s.creator_id;
+
+-- Текущие участники (users.session_id) сохраняют доступ: наблюдатели - по is_watcher, остальные голосуют
+INSERT INTO public.session_participants (session_id, user_id, role, joined_at)
+SELECT u.session_id, u.id, CASE WHEN u.is_watcher THEN 'observer' ELSE 'voter' END, u.created_at
+FROM public.users u
+WHERE u.session_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- Все, кто уже голосовал, становятся голосующими своих сессий
+INSERT INTO public.session_participants (session_id, user_id, role, joined_at)
+SELECT v.session_id, v.user_id, 'voter', MIN(v.created_at)
+FROM public.votes v
+GROUP BY v.session_id, v.user_id
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.session_participants;
+ALTER TABLE public.users DROP CONSTRAINT IF EXISTS ck_users_role;
+ALTER TABLE public.users DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
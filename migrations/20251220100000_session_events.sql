@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Соведущие управляют сессией наравне с ведущим, но не могут передавать права ведущего
+ALTER TABLE public.session_participants DROP CONSTRAINT ck_session_participants_role;
+ALTER TABLE public.session_participants ADD CONSTRAINT ck_session_participants_role
+    CHECK (role IN ('facilitator', 'co_facilitator', 'voter', 'observer'));
+
+-- Ведущий в сессии всегда один
+CREATE UNIQUE INDEX ix_session_participants_facilitator ON public.session_participants (session_id)
+    WHERE role = 'facilitator';
+
+-- История сессии: передача прав, смена ролей, вскрытие и сброс карт
+CREATE TABLE public.session_events (
+                                       id             UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                       session_id     UUID NOT NULL REFERENCES public.sessions ON DELETE CASCADE,
+                                       actor_id       UUID REFERENCES public.users ON DELETE SET NULL,
+                                       event_type     VARCHAR NOT NULL,
+                                       target_user_id UUID REFERENCES public.users ON DELETE SET NULL,
+                                       created_at     TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+ALTER TABLE public.session_events OWNER TO agile_poker_user;
+
+CREATE INDEX ix_session_events_session_id_created_at ON public.session_events (session_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.session_events;
+DROP INDEX IF EXISTS public.ix_session_participants_facilitator;
+UPDATE public.session_participants SET role = 'voter' WHERE role = 'co_facilitator';
+ALTER TABLE public.session_participants DROP CONSTRAINT ck_session_participants_role;
+ALTER TABLE public.session_participants ADD CONSTRAINT ck_session_participants_role
+    CHECK (role IN ('facilitator', 'voter', 'observer'));
+-- +goose StatementEnd
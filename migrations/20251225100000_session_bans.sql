@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Заблокированные в сессии пользователи не могут войти в неё повторно до разблокировки
+CREATE TABLE public.session_bans (
+                                     session_id UUID NOT NULL REFERENCES public.sessions ON DELETE CASCADE,
+                                     user_id    UUID NOT NULL REFERENCES public.users ON DELETE CASCADE,
+                                     banned_by  UUID REFERENCES public.users ON DELETE SET NULL,
+                                     reason     VARCHAR,
+                                     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+                                     PRIMARY KEY (session_id, user_id)
+);
+ALTER TABLE public.session_bans OWNER TO agile_poker_user;
+
+CREATE INDEX ix_session_events_target_user_id ON public.session_events (session_id, target_user_id, event_type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.ix_session_events_target_user_id;
+DROP TABLE IF EXISTS public.session_bans;
+-- +goose StatementEnd
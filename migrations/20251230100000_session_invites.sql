@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Короткий код для входа в сессию без знания её id
+ALTER TABLE public.sessions ADD COLUMN join_code VARCHAR;
+
+DO $$
+DECLARE
+    session_row RECORD;
+    alphabet    CONSTANT TEXT := 'ABCDEFGHJKMNPQRSTUVWXYZ23456789';
+    code        TEXT;
+BEGIN
+    FOR session_row IN SELECT id FROM public.sessions LOOP
+        LOOP
+            SELECT string_agg(substr(alphabet, 1 + floor(random() * length(alphabet))::INT, 1), '')
+            INTO code
+            FROM generate_series(1, 6);
+
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM public.sessions WHERE join_code = code);
+        END LOOP;
+
+        UPDATE public.sessions SET join_code = code WHERE id = session_row.id;
+    END LOOP;
+END $$;
+
+ALTER TABLE public.sessions ALTER COLUMN join_code SET NOT NULL;
+CREATE UNIQUE INDEX ix_sessions_join_code ON public.sessions (join_code);
+
+-- Приглашения по ссылке. Сама ссылка не хранится: она подписывается секретом сервера по id приглашения.
+CREATE TABLE public.session_invites (
+                                        id         UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                        session_id UUID NOT NULL REFERENCES public.sessions ON DELETE CASCADE,
+                                        created_by UUID REFERENCES public.users ON DELETE SET NULL,
+                                        expires_at TIMESTAMP WITH TIME ZONE,
+                                        max_uses   INTEGER,
+                                        uses       INTEGER NOT NULL DEFAULT 0,
+                                        created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+ALTER TABLE public.session_invites OWNER TO agile_poker_user;
+
+CREATE INDEX ix_session_invites_session_id ON public.session_invites (session_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.session_invites;
+DROP INDEX IF EXISTS public.ix_sessions_join_code;
+ALTER TABLE public.sessions DROP COLUMN IF EXISTS join_code;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ограничение входа в сессию: код-пароль (хранится только хеш) и список разрешённых доменов email
+ALTER TABLE public.sessions ADD COLUMN passcode_hash VARCHAR;
+ALTER TABLE public.sessions ADD COLUMN allowed_email_domains TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.sessions DROP COLUMN IF EXISTS allowed_email_domains;
+ALTER TABLE public.sessions DROP COLUMN IF EXISTS passcode_hash;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Список задач для оценки в сессии; estimate пустой, пока задача не оценена
+CREATE TABLE public.session_stories (
+                                        id         UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                        session_id UUID NOT NULL REFERENCES public.sessions ON DELETE CASCADE,
+                                        title      VARCHAR NOT NULL,
+                                        position   INTEGER NOT NULL,
+                                        estimate   VARCHAR,
+                                        created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+ALTER TABLE public.session_stories OWNER TO agile_poker_user;
+
+-- Позиция задает порядок задач и не может повторяться внутри сессии
+CREATE UNIQUE INDEX ix_session_stories_session_id_position ON public.session_stories (session_id, position);
+
+-- Таймер раунда
+ALTER TABLE public.sessions ADD COLUMN timer_seconds INTEGER;
+
+-- Сохранённые настройки сессии, из которых можно быстро создать новую
+CREATE TABLE public.session_templates (
+                                          id            UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+                                          owner_id      UUID NOT NULL REFERENCES public.users ON DELETE CASCADE,
+                                          name          VARCHAR NOT NULL,
+                                          deck_type     VARCHAR NOT NULL,
+                                          allow_emoji   BOOLEAN NOT NULL DEFAULT TRUE,
+                                          auto_reveal   BOOLEAN NOT NULL DEFAULT TRUE,
+                                          timer_seconds INTEGER,
+                                          stories       TEXT[] NOT NULL DEFAULT '{}',
+                                          created_at    TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+                                          updated_at    TIMESTAMP WITH TIME ZONE
+);
+ALTER TABLE public.session_templates OWNER TO agile_poker_user;
+
+CREATE INDEX ix_session_templates_owner_id ON public.session_templates (owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.session_templates;
+ALTER TABLE public.sessions DROP COLUMN IF EXISTS timer_seconds;
+DROP TABLE IF EXISTS public.session_stories;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Архивные сессии скрываются из списка по умолчанию
+ALTER TABLE public.sessions ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Постраничный список сессий пользователя: курсор по (created_at, id) или (name, id)
+CREATE INDEX ix_sessions_creator_id_created_at ON public.sessions (creator_id, created_at, id);
+CREATE INDEX ix_sessions_creator_id_name ON public.sessions (creator_id, name, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS public.ix_sessions_creator_id_name;
+DROP INDEX IF EXISTS public.ix_sessions_creator_id_created_at;
+ALTER TABLE public.sessions DROP COLUMN IF EXISTS archived_at;
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+package hash
+
+import (
+	"errors"
+)
+
+var (
+	// ErrPasswordMismatch - пароль не соответствует хешу
+	ErrPasswordMismatch = errors.New("password does not match hash")
+	// ErrUnknownHashFormat - ни один из алгоритмов не распознал формат хеша
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
+)
+
+// Hasher - алгоритм хеширования паролей
+type Hasher interface {
+	Hash(password string) (string, error)
+	Check(password, hashedPassword string) error
+	// Supports определяет по префиксу, создан ли хеш этим алгоритмом
+	Supports(hashedPassword string) bool
+	// NeedsRehash возвращает true, если хеш создан с устаревшими параметрами
+	NeedsRehash(hashedPassword string) bool
+}
+
+// PasswordHasher хеширует новые пароли основным алгоритмом, а проверяет хеши любого
+// из подключённых алгоритмов. Так можно сменить алгоритм без принудительного сброса паролей.
+type PasswordHasher struct {
+	primary Hasher
+	hashers []Hasher
+}
+
+func NewPasswordHasher(primary Hasher, legacy ...Hasher) *PasswordHasher {
+	return &PasswordHasher{
+		primary: primary,
+		hashers: append([]Hasher{primary}, legacy...),
+	}
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	return h.primary.Hash(password)
+}
+
+func (h *PasswordHasher) Check(password, hashedPassword string) error {
+	for _, hasher := range h.hashers {
+		if hasher.Supports(hashedPassword) {
+			return hasher.Check(password, hashedPassword)
+		}
+	}
+	return ErrUnknownHashFormat
+}
+
+func (h *PasswordHasher) Supports(hashedPassword string) bool {
+	for _, hasher := range h.hashers {
+		if hasher.Supports(hashedPassword) {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsRehash возвращает true для хешей другого алгоритма или с устаревшими параметрами основного
+func (h *PasswordHasher) NeedsRehash(hashedPassword string) bool {
+	if !h.primary.Supports(hashedPassword) {
+		return true
+	}
+	return h.primary.NeedsRehash(hashedPassword)
+}
@@ -0,0 +1,27 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Sign возвращает HMAC-SHA256 от message в base64url. Подпись позволяет выдавать ссылки,
+// которые сервер проверяет без хранения самой ссылки.
+func Sign(secret []byte, message string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature сравнивает подпись за постоянное время
+func VerifySignature(secret []byte, message, signature string) bool {
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(message))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,92 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// hashPrefixLength - длина префикса SHA-1, как в range API Have I Been Pwned
+const hashPrefixLength = 5
+
+// BreachedList - локальный список утёкших паролей.
+// Хранятся только SHA-1 хеши, сгруппированные по 5-символьному префиксу (k-anonymity),
+// поэтому файл можно получить выгрузкой HIBP без раскрытия самих паролей.
+type BreachedList struct {
+	ranges map[string]map[string]struct{}
+}
+
+// LoadBreachedList читает файл, где каждая строка - SHA-1 пароля в hex,
+// опционально с количеством утечек через двоеточие ("HASH:COUNT"). Пустые строки и строки с # пропускаются.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	list := &BreachedList{ranges: make(map[string]map[string]struct{})}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash := strings.ToUpper(strings.SplitN(line, ":", 2)[0])
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("invalid sha1 hash in breached password list at line %d", lineNumber)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("invalid sha1 hash in breached password list at line %d", lineNumber)
+		}
+
+		prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+		if list.ranges[prefix] == nil {
+			list.ranges[prefix] = make(map[string]struct{})
+		}
+		list.ranges[prefix][suffix] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+
+	return list, nil
+}
+
+// Contains проверяет, встречается ли пароль в списке утечек
+func (l *BreachedList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, ok := l.ranges[hash[:hashPrefixLength]]
+	if !ok {
+		return false
+	}
+	_, found := suffixes[hash[hashPrefixLength:]]
+	return found
+}
+
+// Size возвращает количество хешей в списке
+func (l *BreachedList) Size() int {
+	if l == nil {
+		return 0
+	}
+
+	size := 0
+	for _, suffixes := range l.ranges {
+		size += len(suffixes)
+	}
+	return size
+}
@@ -0,0 +1,77 @@
+package password
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// BcryptMaxLength - bcrypt учитывает только первые 72 байта пароля
+const BcryptMaxLength = 72
+
+// Коды нарушений политики паролей
+const (
+	ViolationTooShort      = "too_short"
+	ViolationTooLong       = "too_long"
+	ViolationMissingUpper  = "missing_upper"
+	ViolationMissingLower  = "missing_lower"
+	ViolationMissingDigit  = "missing_digit"
+	ViolationMissingSymbol = "missing_symbol"
+)
+
+// Policy - требования к сложности пароля
+type Policy struct {
+	MinLength     int // в символах
+	MaxLength     int // в байтах, не больше BcryptMaxLength
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// Validate возвращает коды всех нарушенных требований; пустой результат - пароль подходит
+func (p Policy) Validate(password string) []string {
+	var violations []string
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, ViolationTooShort)
+	}
+	if len(password) > p.maxLength() {
+		violations = append(violations, ViolationTooLong)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, ViolationMissingUpper)
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, ViolationMissingLower)
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, ViolationMissingDigit)
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, ViolationMissingSymbol)
+	}
+
+	return violations
+}
+
+func (p Policy) maxLength() int {
+	if p.MaxLength <= 0 || p.MaxLength > BcryptMaxLength {
+		return BcryptMaxLength
+	}
+	return p.MaxLength
+}